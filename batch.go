@@ -0,0 +1,176 @@
+package creditcard
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ValidateAll validates each of the cards and returns the validation results in the same order as the cards
+func ValidateAll(cards []*Card) []*Validation {
+	vals := make([]*Validation, len(cards))
+	for i, card := range cards {
+		vals[i] = card.Validate()
+	}
+	return vals
+}
+
+// ValidateAllConcurrent validates the cards using the given number of workers and returns the validation
+// results in the same order as the cards. At least one worker is used
+func ValidateAllConcurrent(cards []*Card, workers int) []*Validation {
+	if workers < 1 {
+		workers = 1
+	}
+
+	vals := make([]*Validation, len(cards))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				vals[i] = cards[i].Validate()
+			}
+		}()
+	}
+
+	for i := range cards {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	return vals
+}
+
+// ValidateContext validates the card like Validate, but returns the error of the context
+// without validating the card when the context is canceled or its deadline is exceeded
+func (c *Card) ValidateContext(ctx context.Context) (*Validation, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Validate(), nil
+}
+
+// ValidateAllContext validates each of the cards and returns the validation results in the same order as
+// the cards. The context is checked before each card, so when the context is canceled the remaining cards
+// are not validated. In that case the results of the cards that were not validated are nil and the error
+// of the context is returned
+func ValidateAllContext(ctx context.Context, cards []*Card) ([]*Validation, error) {
+	vals := make([]*Validation, len(cards))
+	for i, card := range cards {
+		val, err := card.ValidateContext(ctx)
+		if err != nil {
+			return vals, err
+		}
+		vals[i] = val
+	}
+	return vals, nil
+}
+
+// RowError describes a malformed row in CSV input
+type RowError struct {
+	// Row is the line number of the row in the input, starting at 1 and including the header row, blank lines, and
+	// comments, so the row can be found in the input
+	Row int
+	// Err describes what is wrong with the row
+	Err error
+}
+
+// Error returns the row number and the description of the error
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Row, e.Err.Error())
+}
+
+// Unwrap returns the error that describes what is wrong with the row
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// RowErrors contains all malformed rows in CSV input
+type RowErrors []*RowError
+
+// Error returns the errors of all malformed rows
+func (e RowErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ValidateCSV reads cards from CSV input and validates them. Each row contains the number, expiry month,
+// expiry year, CVV, and optionally the type of a card, in that order. A header row starting with "number"
+// is skipped. The validation results of all well-formed rows are returned in the order of the input. When
+// rows are malformed, they are reported in a RowErrors error that is returned together with the results of
+// the other rows. Any other error means the input couldn't be read
+func ValidateCSV(r io.Reader) ([]*Validation, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	vals := make([]*Validation, 0)
+	var rowErrs RowErrors
+
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return vals, err
+		}
+
+		if first && strings.EqualFold(strings.TrimSpace(record[0]), "number") {
+			continue
+		}
+
+		card, err := parseCSVRecord(record)
+		if err != nil {
+			// The reader skips blank lines, so the line of the record is taken from the reader
+			line, _ := reader.FieldPos(0)
+			rowErrs = append(rowErrs, &RowError{Row: line, Err: err})
+			continue
+		}
+		vals = append(vals, card.Validate())
+	}
+
+	if len(rowErrs) > 0 {
+		return vals, rowErrs
+	}
+	return vals, nil
+}
+
+// parseCSVRecord creates a card from a CSV record with the number, expiry month, expiry year, CVV, and optional type
+func parseCSVRecord(record []string) (*Card, error) {
+	if len(record) != 4 && len(record) != 5 {
+		return nil, fmt.Errorf("expected 4 or 5 fields, got %d", len(record))
+	}
+
+	month, err := strconv.Atoi(strings.TrimSpace(record[1]))
+	if err != nil {
+		return nil, fmt.Errorf("expiry month '%s' is not a number", record[1])
+	}
+
+	year, err := strconv.Atoi(strings.TrimSpace(record[2]))
+	if err != nil {
+		return nil, fmt.Errorf("expiry year '%s' is not a number", record[2])
+	}
+
+	card := &Card{
+		Number:      record[0],
+		ExpiryMonth: month,
+		ExpiryYear:  year,
+		CVV:         strings.TrimSpace(record[3]),
+	}
+	if len(record) == 5 {
+		card.Type = strings.TrimSpace(record[4])
+	}
+	return card, nil
+}
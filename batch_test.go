@@ -0,0 +1,127 @@
+package creditcard
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// batchCards returns a set of cards with a mix of valid and invalid values
+func batchCards() []*Card {
+	return []*Card{
+		{Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123"},
+		{Number: "378282246310005", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "1234"},
+		{Type: "Something", Number: "5019717010103742", ExpiryMonth: 11, ExpiryYear: 2019, CVV: "1234"},
+		{Number: "0000000000", ExpiryMonth: 13, ExpiryYear: 1899, CVV: "12a"},
+		{Number: "5555555555554444", ExpiryMonth: 1, ExpiryYear: 2021, CVV: "123"},
+		{Number: "6011111111111117", ExpiryMonth: 2, ExpiryYear: 2022, CVV: "12"},
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	assert := assert.New(t)
+
+	cards := batchCards()
+	vals := ValidateAll(cards)
+	assert.Len(vals, len(cards))
+	for i, val := range vals {
+		assert.Equal(cards[i].Validate(), val)
+	}
+
+	for _, workers := range []int{-1, 0, 1, 3, 10} {
+		concurrent := ValidateAllConcurrent(batchCards(), workers)
+		assert.Equal(ValidateAll(batchCards()), concurrent, "workers: %d", workers)
+	}
+
+	assert.Empty(ValidateAll(nil))
+	assert.Empty(ValidateAllConcurrent(nil, 4))
+}
+
+func TestValidateContext(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123"}
+	val, err := card.ValidateContext(context.Background())
+	assert.NoError(err)
+	assert.NotNil(val)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	val, err = card.ValidateContext(ctx)
+	assert.Equal(context.Canceled, err)
+	assert.Nil(val)
+}
+
+func TestValidateAllContext(t *testing.T) {
+	assert := assert.New(t)
+
+	cards := batchCards()
+	vals, err := ValidateAllContext(context.Background(), cards)
+	assert.NoError(err)
+	assert.Equal(ValidateAll(batchCards()), vals)
+
+	// Cancel the context while the second card is being validated
+	ctx, cancel := context.WithCancel(context.Background())
+	cards = batchCards()
+	cards[1].Apply(WithClock(func() time.Time {
+		cancel()
+		return time.Now()
+	}))
+
+	vals, err = ValidateAllContext(ctx, cards)
+	assert.Equal(context.Canceled, err)
+	assert.Len(vals, len(cards))
+	assert.NotNil(vals[0])
+	assert.NotNil(vals[1])
+	for _, val := range vals[2:] {
+		assert.Nil(val)
+	}
+	assert.Empty(cards[3].Type)
+}
+
+func TestValidateCSV(t *testing.T) {
+	assert := assert.New(t)
+
+	input := `number,expMonth,expYear,cvv,type
+4012888888881881,11,2020,123,Visa
+378282246310005,11,2020,1234
+5019717010103742,eleven,2020,123
+6011111111111117,11,2020,123,Mastercard
+4012888888881881,11
+`
+	vals, err := ValidateCSV(strings.NewReader(input))
+	assert.Len(vals, 3)
+	assert.Equal("Visa", vals[0].Card.Type)
+	assert.True(vals[0].ValidCardNumber)
+	assert.Equal("American Express", vals[1].Card.Type)
+	assert.True(vals[1].ValidCVV)
+	assert.True(vals[2].HasError(ErrTypeMismatch))
+
+	var rowErrs RowErrors
+	assert.True(errors.As(err, &rowErrs))
+	assert.Len(rowErrs, 2)
+	assert.Equal(4, rowErrs[0].Row)
+	assert.Equal(6, rowErrs[1].Row)
+	assert.EqualError(err, "row 4: expiry month 'eleven' is not a number; row 6: expected 4 or 5 fields, got 2")
+
+	vals, err = ValidateCSV(strings.NewReader("4012888888881881,11,2020,123\n"))
+	assert.NoError(err)
+	assert.Len(vals, 1)
+
+	vals, err = ValidateCSV(strings.NewReader(""))
+	assert.NoError(err)
+	assert.Empty(vals)
+
+	// Blank lines are counted, so the row matches the line in the input
+	vals, err = ValidateCSV(strings.NewReader("number,expMonth,expYear,cvv\n\n4012888888881881,11,2030,123\n\nx,y\n"))
+	assert.Len(vals, 1)
+	assert.EqualError(err, "row 5: expected 4 or 5 fields, got 2")
+
+	_, err = ValidateCSV(strings.NewReader("4012888888881881,\"11,2020,123\n"))
+	assert.Error(err)
+	assert.False(errors.As(err, &rowErrs))
+}
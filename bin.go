@@ -0,0 +1,44 @@
+package creditcard
+
+import "strings"
+
+// defaultBINLength is the number of digits of a BIN, unless configured otherwise
+const defaultBINLength = 6
+
+// BIN returns the bank identification number, which is the first six digits of the card number after removing
+// spaces and dashes. Cards configured with WithBINLength return that number of digits instead, for example to
+// get the 8 digit BIN of the newer standard. An empty string is returned when the card number is too short
+func (c *Card) BIN() string {
+	length := c.opts.binLength
+	if length <= 0 {
+		length = defaultBINLength
+	}
+
+	number := c.number()
+	if len(number) < length {
+		return ""
+	}
+	return number[:length]
+}
+
+// SameBIN reports whether both card numbers share the same BIN of binLen digits after removing spaces and dashes,
+// for example to group cards by issuer. An error is returned when either number contains other characters or is
+// shorter than binLen digits
+func SameBIN(a, b string, binLen int) (bool, error) {
+	if binLen <= 0 {
+		return false, newError(ErrInvalidLength, "BIN length %d is not valid", binLen)
+	}
+
+	var bins [2]string
+	for i, number := range []string{a, b} {
+		number = separators.Replace(strings.TrimSpace(number))
+		if err := checkDigits(number); err != nil {
+			return false, err
+		}
+		if len(number) < binLen {
+			return false, newError(ErrInvalidLength, "card number has %d digits, which is shorter than the BIN length %d", len(number), binLen)
+		}
+		bins[i] = number[:binLen]
+	}
+	return bins[0] == bins[1], nil
+}
@@ -0,0 +1,55 @@
+package creditcard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBIN(t *testing.T) {
+	assert := assert.New(t)
+
+	card := NewCard("4012 8888 8888 1881")
+	assert.Equal("401288", card.BIN())
+
+	card = NewCard("4012 8888 8888 1881", WithBINLength(8))
+	assert.Equal("40128888", card.BIN())
+
+	card = NewCard("40128")
+	assert.Equal("", card.BIN())
+
+	card = NewCard("401288", WithBINLength(8))
+	assert.Equal("", card.BIN())
+
+	card = NewCard("401288")
+	assert.Equal("401288", card.BIN())
+}
+
+func TestSameBIN(t *testing.T) {
+	assert := assert.New(t)
+
+	same, err := SameBIN("4012 8888 8888 1881", "4012-8812-3456-7890", 6)
+	assert.NoError(err)
+	assert.True(same)
+
+	same, err = SameBIN("4012888888881881", "4111111111111111", 6)
+	assert.NoError(err)
+	assert.False(same)
+
+	same, err = SameBIN("4012888888881881", "40128899", 8)
+	assert.NoError(err)
+	assert.False(same)
+
+	_, err = SameBIN("4012888888881881", "40128", 6)
+	assert.True(errors.Is(err, ErrInvalidLength))
+
+	_, err = SameBIN("", "4012888888881881", 6)
+	assert.True(errors.Is(err, ErrInvalidLength))
+
+	_, err = SameBIN("4012888888881881", "4012888888881881", 0)
+	assert.True(errors.Is(err, ErrInvalidLength))
+
+	_, err = SameBIN("4012a8888888881881", "4012888888881881", 6)
+	assert.True(errors.Is(err, ErrNonDigit))
+}
@@ -0,0 +1,240 @@
+package creditcard
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// binaryVersion is the version of the binary representation of a card
+const binaryVersion = 1
+
+// Flags of the binary representation of a card, which store the results of the checks performed by Validate
+const (
+	binaryValidCardNumber byte = 1 << iota
+	binaryValidExpiryMonth
+	binaryValidExpiryYear
+	binaryValidCVV
+	binaryIsExpired
+	binaryTypeProvided
+)
+
+// binaryErrors are the errors that are recognized in the binary representation of a card, so errors of a restored
+// validation result still match them with errors.Is. Errors are stored by their position, so new errors are only
+// ever added at the end
+var binaryErrors = []error{
+	ErrInvalidMonth, ErrInvalidYear, ErrMissingExpiry, ErrIncompleteExpiry, ErrExpired, ErrUnknownType,
+	ErrTypeMismatch, ErrEmptyNumber, ErrTypeNotAccepted, ErrNonDigit, ErrNonASCIIDigit, ErrInvalidLength,
+	ErrLengthOutOfRange, ErrChecksumFailed, ErrInvalidNumber, ErrCVVNotNumeric, ErrCVVMismatch,
+	ErrExpiringSoon, ErrSuspiciousPattern, ErrTestCard,
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface with a compact representation of the validated
+// card, for example to cache validation results. The card is validated, and the card type determined from the card
+// number, the masked card number, the expiry date, and the result of each check are stored, together with the errors
+// and warnings. The full card number and the CVV are never included, so the card can't be validated again after it
+// is restored; use CachedValidation to get the stored result instead
+func (c *Card) MarshalBinary() ([]byte, error) {
+	val := c.Validate()
+
+	var flags byte
+	if val.ValidCardNumber {
+		flags |= binaryValidCardNumber
+	}
+	if val.ValidExpiryMonth {
+		flags |= binaryValidExpiryMonth
+	}
+	if val.ValidExpiryYear {
+		flags |= binaryValidExpiryYear
+	}
+	if val.ValidCVV {
+		flags |= binaryValidCVV
+	}
+	if val.IsExpired {
+		flags |= binaryIsExpired
+	}
+	if val.TypeProvided {
+		flags |= binaryTypeProvided
+	}
+
+	// The card type is stored by name, since the values of card types change when card types are added
+	var brand string
+	if val.DetectedType != Unknown {
+		brand = val.DetectedType.String()
+	}
+
+	data := []byte{binaryVersion, flags}
+	data = binary.AppendVarint(data, int64(val.Card.ExpiryMonth))
+	data = binary.AppendVarint(data, int64(val.Card.ExpiryYear))
+	data = appendString(data, brand)
+	data = appendString(data, c.MaskNumber())
+	data = appendErrors(data, val.errs)
+	data = appendErrors(data, val.warns)
+	return data, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface for data created by MarshalBinary. The card gets
+// the detected card type as card type, the masked card number, and the expiry date, while the CVV is cleared. The
+// stored validation result is available through CachedValidation
+func (c *Card) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return errors.New("binary card is too short")
+	}
+	if data[0] != binaryVersion {
+		return fmt.Errorf("binary card version %d is not supported", data[0])
+	}
+	flags := data[1]
+	data = data[2:]
+
+	month, data, err := readVarint(data)
+	if err != nil {
+		return err
+	}
+	year, data, err := readVarint(data)
+	if err != nil {
+		return err
+	}
+	brand, data, err := readString(data)
+	if err != nil {
+		return err
+	}
+	number, data, err := readString(data)
+	if err != nil {
+		return err
+	}
+	errs, data, err := readErrors(data)
+	if err != nil {
+		return err
+	}
+	warns, data, err := readErrors(data)
+	if err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		return errors.New("binary card has trailing data")
+	}
+
+	val := &Validation{
+		DetectedType:     Unknown,
+		TypeProvided:     flags&binaryTypeProvided != 0,
+		ValidCardNumber:  flags&binaryValidCardNumber != 0,
+		ValidExpiryMonth: flags&binaryValidExpiryMonth != 0,
+		ValidExpiryYear:  flags&binaryValidExpiryYear != 0,
+		ValidCVV:         flags&binaryValidCVV != 0,
+		IsExpired:        flags&binaryIsExpired != 0,
+		Errors:           make([]string, 0, len(errs)),
+		Warnings:         make([]string, 0, len(warns)),
+	}
+	if brand != "" {
+		val.DetectedType = cardTypeNamed(brand)
+	}
+	for _, err := range errs {
+		val.addError(err)
+	}
+	for _, warn := range warns {
+		val.addWarning(warn)
+	}
+
+	c.Type = brand
+	c.Number = number
+	c.ExpiryMonth = int(month)
+	c.ExpiryYear = int(year)
+	c.CVV = ""
+	c.validation = val
+	return nil
+}
+
+// CachedValidation returns the validation result that was stored with MarshalBinary, for cards restored with
+// UnmarshalBinary. The result reflects the moment the card was marshaled, so for example a card that expired since
+// then is not reported as expired. The second return value is false for cards that weren't restored
+func (c *Card) CachedValidation() (*Validation, bool) {
+	if c.validation == nil {
+		return nil, false
+	}
+
+	val := *c.validation
+	card := *c
+	card.validation = nil
+	val.Card = &card
+	val.Errors = append([]string(nil), val.Errors...)
+	val.errs = append([]error(nil), val.errs...)
+	val.Warnings = append([]string(nil), val.Warnings...)
+	val.warns = append([]error(nil), val.warns...)
+	return &val, true
+}
+
+// appendString appends the length of the string and the string itself to the data
+func appendString(data []byte, s string) []byte {
+	data = binary.AppendUvarint(data, uint64(len(s)))
+	return append(data, s...)
+}
+
+// appendErrors appends the number of errors, followed by the position of each error in binaryErrors, where 0 means
+// the error isn't one of them, and the message of each error
+func appendErrors(data []byte, errs []error) []byte {
+	data = binary.AppendUvarint(data, uint64(len(errs)))
+	for _, err := range errs {
+		var kind uint64
+		for i, target := range binaryErrors {
+			if errors.Is(err, target) {
+				kind = uint64(i + 1)
+				break
+			}
+		}
+		data = binary.AppendUvarint(data, kind)
+		data = appendString(data, err.Error())
+	}
+	return data
+}
+
+// readVarint reads a varint from the data and returns the remaining data
+func readVarint(data []byte) (int64, []byte, error) {
+	value, n := binary.Varint(data)
+	if n <= 0 {
+		return 0, nil, errors.New("binary card is malformed")
+	}
+	return value, data[n:], nil
+}
+
+// readString reads a string written by appendString from the data and returns the remaining data
+func readString(data []byte) (string, []byte, error) {
+	length, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < length {
+		return "", nil, errors.New("binary card is malformed")
+	}
+	data = data[n:]
+	return string(data[:length]), data[length:], nil
+}
+
+// readErrors reads errors written by appendErrors from the data and returns the remaining data. Errors keep their
+// message and wrap the error of binaryErrors they were stored as
+func readErrors(data []byte) ([]error, []byte, error) {
+	count, n := binary.Uvarint(data)
+	if n <= 0 || count > uint64(len(data)) {
+		return nil, nil, errors.New("binary card is malformed")
+	}
+	data = data[n:]
+
+	errs := make([]error, 0, count)
+	for i := uint64(0); i < count; i++ {
+		kind, n := binary.Uvarint(data)
+		if n <= 0 || kind > uint64(len(binaryErrors)) {
+			return nil, nil, errors.New("binary card is malformed")
+		}
+		msg, rest, err := readString(data[n:])
+		if err != nil {
+			return nil, nil, err
+		}
+		data = rest
+
+		switch {
+		case kind == 0:
+			errs = append(errs, errors.New(msg))
+		case binaryErrors[kind-1].Error() == msg:
+			errs = append(errs, binaryErrors[kind-1])
+		default:
+			errs = append(errs, newError(binaryErrors[kind-1], "%s", msg))
+		}
+	}
+	return errs, data, nil
+}
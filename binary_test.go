@@ -0,0 +1,106 @@
+package creditcard
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) })
+
+	card := NewCard("4012888888881881", WithExpiry(11, 2030), WithCVV("123"), clock)
+	b, err := card.MarshalBinary()
+	assert.NoError(err)
+	assert.False(bytes.Contains(b, []byte("4012888888881881")))
+	assert.False(bytes.Contains(b, []byte("123")))
+
+	var decoded Card
+	assert.NoError(decoded.UnmarshalBinary(b))
+	assert.Equal("Visa", decoded.Type)
+	assert.Equal("************1881", decoded.Number)
+	assert.Equal(11, decoded.ExpiryMonth)
+	assert.Equal(2030, decoded.ExpiryYear)
+	assert.Empty(decoded.CVV)
+
+	val, ok := decoded.CachedValidation()
+	assert.True(ok)
+	assert.True(val.Valid())
+	assert.Equal(Visa, val.DetectedType)
+	assert.False(val.TypeProvided)
+	assert.True(val.ValidCardNumber)
+	assert.True(val.ValidExpiryMonth)
+	assert.True(val.ValidExpiryYear)
+	assert.True(val.ValidCVV)
+	assert.False(val.IsExpired)
+	assert.Empty(val.Errors)
+	assert.Equal([]string{"card number is a test number"}, val.Warnings)
+	assert.True(val.HasWarning(ErrTestCard))
+	assert.Equal("************1881", val.Card.Number)
+
+	// The full card number can't be recovered from the binary representation
+	_, err = decoded.Brand()
+	assert.Error(err)
+
+	_, ok = card.CachedValidation()
+	assert.False(ok)
+}
+
+func TestMarshalBinaryInvalidCard(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) })
+
+	// The given card type is not stored, only the card type determined from the card number
+	card := NewCard("5019717010103742", WithExpiry(9, 2020), WithCVV("1234"), clock)
+	card.Type = "Something"
+	expected := card.Validate()
+	b, err := card.MarshalBinary()
+	assert.NoError(err)
+	assert.False(bytes.Contains(b, []byte("5019717010103742")))
+
+	var decoded Card
+	assert.NoError(decoded.UnmarshalBinary(b))
+	assert.Equal("Dankort", decoded.Type)
+
+	val, ok := decoded.CachedValidation()
+	assert.True(ok)
+	assert.False(val.Valid())
+	assert.Equal(Dankort, val.DetectedType)
+	assert.True(val.TypeProvided)
+	assert.False(val.ValidCardNumber)
+	assert.False(val.ValidCVV)
+	assert.True(val.IsExpired)
+	assert.Equal(expected.Errors, val.Errors)
+	assert.Equal(expected.FailedChecks(), val.FailedChecks())
+	assert.True(val.HasError(ErrTypeMismatch))
+	assert.True(val.HasError(ErrExpired))
+	assert.True(val.HasError(ErrCVVMismatch))
+
+	// Cards with an unknown card type are stored without a card type
+	card = NewCard("0000000000000000", WithExpiry(11, 2030), WithCVV("123"), clock)
+	b, err = card.MarshalBinary()
+	assert.NoError(err)
+	assert.NoError(decoded.UnmarshalBinary(b))
+	assert.Empty(decoded.Type)
+	val, _ = decoded.CachedValidation()
+	assert.Equal(Unknown, val.DetectedType)
+	assert.True(val.HasError(ErrUnknownType))
+}
+
+func TestUnmarshalBinaryInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	b, err := NewCard("4012888888881881", WithExpiry(11, 2030)).MarshalBinary()
+	assert.NoError(err)
+
+	var card Card
+	assert.Error(card.UnmarshalBinary(nil))
+	assert.Error(card.UnmarshalBinary([]byte{2, 0}))
+	assert.Error(card.UnmarshalBinary(b[:len(b)-1]))
+	assert.Error(card.UnmarshalBinary(append(b, 0)))
+}
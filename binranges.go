@@ -0,0 +1,149 @@
+package creditcard
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// loadedRules contains the rules that are loaded at runtime with LoadBINRanges
+var loadedRules struct {
+	sync.RWMutex
+	rules []cardTypeRule
+}
+
+// LoadBINRanges loads BIN ranges from CSV input, so the detection of card types can be updated without a new
+// release. Each row has the fields brand,low,high,minLen,maxLen, like "Visa,4000,4099,16,16". The brand is the
+// name of a built-in card type, or of a card type registered with RegisterCardType. Low and high are the first and
+// last prefix of the range, which must have the same number of digits. The minimum and maximum length limit the
+// lengths of the card numbers that match, where 0 or an empty field means there is no limit. Rows starting with '#'
+// and a header row starting with "brand" are skipped.
+//
+// Loaded ranges take precedence over the built-in ranges, with longer prefixes first, so they can both supplement and
+// override them. Every call adds to the ranges that were loaded before. When any of the rows is malformed, none of
+// the ranges are loaded and RowErrors describing the malformed rows is returned. It is safe to load BIN ranges while
+// cards are being validated
+func LoadBINRanges(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	rules := make([]cardTypeRule, 0)
+	var rowErrs RowErrors
+
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if first && strings.EqualFold(strings.TrimSpace(record[0]), "brand") {
+			continue
+		}
+
+		rule, err := parseBINRange(record)
+		if err != nil {
+			// The reader skips comments and blank lines, so the line of the record is taken from the reader
+			line, _ := reader.FieldPos(0)
+			rowErrs = append(rowErrs, &RowError{Row: line, Err: err})
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	if len(rowErrs) > 0 {
+		return rowErrs
+	}
+
+	loadedRules.Lock()
+	defer loadedRules.Unlock()
+
+	rules = append(loadedRules.rules, rules...)
+	sort.SliceStable(rules, func(i, j int) bool {
+		return len(rules[i].from) > len(rules[j].from)
+	})
+	loadedRules.rules = rules
+	return nil
+}
+
+// parseBINRange parses a row in the brand,low,high,minLen,maxLen format
+func parseBINRange(record []string) (cardTypeRule, error) {
+	if len(record) != 5 {
+		return cardTypeRule{}, fmt.Errorf("expected 5 fields, got %d", len(record))
+	}
+	for i := range record {
+		record[i] = strings.TrimSpace(record[i])
+	}
+
+	cardType := cardTypeNamed(record[0])
+	if cardType == Unknown {
+		return cardTypeRule{}, fmt.Errorf("card type '%s' is not known", record[0])
+	}
+
+	from, to := record[1], record[2]
+	if from == "" || !isDigits(from) || !isDigits(to) || len(from) != len(to) || from > to {
+		return cardTypeRule{}, fmt.Errorf("range '%s' to '%s' is not a valid range of prefixes", from, to)
+	}
+
+	lengths := [2]int{}
+	for i, field := range record[3:] {
+		if field == "" {
+			continue
+		}
+		length, err := strconv.Atoi(field)
+		if err != nil || length < 0 {
+			return cardTypeRule{}, fmt.Errorf("length '%s' is not a valid length", field)
+		}
+		lengths[i] = length
+	}
+	if lengths[1] > 0 && lengths[1] < lengths[0] {
+		return cardTypeRule{}, fmt.Errorf("maximum length %d is less than minimum length %d", lengths[1], lengths[0])
+	}
+
+	return cardTypeRule{
+		cardType: cardType, from: from, to: to, minLength: lengths[0], maxLength: lengths[1],
+	}, nil
+}
+
+// loadedCardType returns the card type of the first loaded rule that matches the number, optionally ignoring its length
+func loadedCardType(number string, prefixOnly bool) (CardType, bool) {
+	loadedRules.RLock()
+	defer loadedRules.RUnlock()
+
+	for i := range loadedRules.rules {
+		if loadedRules.rules[i].matches(number, prefixOnly) {
+			return loadedRules.rules[i].cardType, true
+		}
+	}
+	return Unknown, false
+}
+
+// loadedCardTypes adds the card types of all loaded rules that match the number to the card types
+func loadedCardTypes(cardTypes []CardType, number string) []CardType {
+	loadedRules.RLock()
+	defer loadedRules.RUnlock()
+
+	for i := range loadedRules.rules {
+		rule := &loadedRules.rules[i]
+		if rule.matches(number, false) && !containsType(cardTypes, rule.cardType) {
+			cardTypes = append(cardTypes, rule.cardType)
+		}
+	}
+	return cardTypes
+}
+
+// loadedRuleList returns a copy of the loaded rules, ordered by specificity
+func loadedRuleList() []cardTypeRule {
+	loadedRules.RLock()
+	defer loadedRules.RUnlock()
+
+	return append([]cardTypeRule(nil), loadedRules.rules...)
+}
@@ -0,0 +1,87 @@
+package creditcard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// restoreLoadedRules restores the rules loaded with LoadBINRanges when the test finishes, so rules that are loaded
+// by the test don't affect other tests
+func restoreLoadedRules(t *testing.T) {
+	loadedRules.RLock()
+	rules := append([]cardTypeRule(nil), loadedRules.rules...)
+	loadedRules.RUnlock()
+
+	t.Cleanup(func() {
+		loadedRules.Lock()
+		defer loadedRules.Unlock()
+		loadedRules.rules = rules
+	})
+}
+
+func TestLoadBINRanges(t *testing.T) {
+	assert := assert.New(t)
+	restoreLoadedRules(t)
+
+	card := Card{Number: "3600000000000008"}
+	cardType, _ := card.DetectType()
+	assert.Equal(Unknown, cardType)
+
+	err := LoadBINRanges(strings.NewReader(`brand,low,high,minLen,maxLen
+# Diners Club International numbers of 16 digits
+Diners Club International,36,36,16,16
+Visa,400000,400000,,
+`))
+	assert.NoError(err)
+
+	// Loaded ranges supplement the built-in ranges
+	cardType, err = card.DetectType()
+	assert.NoError(err)
+	assert.Equal(DinersClubInternational, cardType)
+
+	card = Card{Number: "36227206271667"}
+	cardType, _ = card.DetectType()
+	assert.Equal(DinersClubInternational, cardType)
+
+	// Loaded ranges override the built-in ranges
+	assert.NoError(LoadBINRanges(strings.NewReader("JCB,4000,4000,0,0\n")))
+	card = Card{Number: "4000000000000002"}
+	cardType, _ = card.DetectType()
+	assert.Equal(Visa, cardType)
+	assert.Equal([]CardType{Visa, JCB}, card.PossibleTypes())
+
+	card = Card{Number: "4000120000000000"}
+	cardType, _ = card.DetectType()
+	assert.Equal(JCB, cardType)
+	assert.Equal([]CardType{JCB, Visa}, card.PossibleTypes())
+
+	// Malformed input doesn't load any of the ranges
+	err = LoadBINRanges(strings.NewReader(`Mastercard,9999,9999,16,16
+Nonexistent,1234,1234,16,16
+Visa,12,3,16,16
+Visa,99,98,16,16
+Visa,1234,1234,16
+Visa,1234,1234,16,x
+Visa,1234,1234,16,15
+`))
+	var rowErrs RowErrors
+	assert.True(errors.As(err, &rowErrs))
+	assert.Len(rowErrs, 6)
+	assert.Equal(2, rowErrs[0].Row)
+	assert.EqualError(rowErrs[0], "row 2: card type 'Nonexistent' is not known")
+
+	// Comments are counted, so the row matches the line in the input
+	err = LoadBINRanges(strings.NewReader(`# BIN ranges
+# brand,low,high,minLen,maxLen
+Visa,4000,4099,16,16
+bogus,1,2,0,0
+`))
+	assert.EqualError(err, "row 4: card type 'bogus' is not known")
+
+	card = Card{Number: "9999000000000000"}
+	cardType, _ = card.DetectType()
+	assert.Equal(Unknown, cardType)
+}
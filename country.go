@@ -0,0 +1,78 @@
+package creditcard
+
+import (
+	_ "embed"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// issuerCountriesCSV contains the BIN ranges of domestic card schemes and the country in which the cards are issued.
+// Only a documented subset of BIN ranges is included, which keeps the embedded table small
+//
+//go:embed issuercountries.csv
+var issuerCountriesCSV string
+
+// issuerCountryRange is a range of BINs that are issued in a single country
+type issuerCountryRange struct {
+	// start is the first BIN of the range
+	start int
+	// end is the last BIN of the range
+	end int
+	// country is the ISO 3166-1 alpha-2 code of the country
+	country string
+}
+
+// issuerCountries contains the parsed BIN ranges, which are loaded the first time they're needed
+var issuerCountries struct {
+	sync.Once
+	ranges []issuerCountryRange
+}
+
+// IssuerCountry returns the ISO 3166-1 alpha-2 code of the country in which the card is issued, based on the first
+// six digits of the card number. Only domestic card schemes, like Mir or Elo, are known, so the second return value
+// is false for most cards of international card schemes, like Visa and Mastercard
+func (c *Card) IssuerCountry() (string, bool) {
+	number := c.number()
+	if len(number) < defaultBINLength || !isDigits(number[:defaultBINLength]) {
+		return "", false
+	}
+	bin, _ := strconv.Atoi(number[:defaultBINLength])
+
+	issuerCountries.Do(func() {
+		issuerCountries.ranges = parseIssuerCountries(issuerCountriesCSV)
+	})
+	for _, r := range issuerCountries.ranges {
+		if bin >= r.start && bin <= r.end {
+			return r.country, true
+		}
+	}
+	return "", false
+}
+
+// parseIssuerCountries parses the lines of the table in the start,end,country format. Empty lines,
+// comments starting with '#', and lines that can't be parsed are skipped
+func parseIssuerCountries(table string) []issuerCountryRange {
+	ranges := make([]issuerCountryRange, 0)
+	for _, line := range strings.Split(table, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) != 3 {
+			continue
+		}
+		start, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		end, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		ranges = append(ranges, issuerCountryRange{start: start, end: end, country: fields[2]})
+	}
+	return ranges
+}
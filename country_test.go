@@ -0,0 +1,45 @@
+package creditcard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssuerCountry(t *testing.T) {
+	assert := assert.New(t)
+
+	for number, expected := range map[string]string{
+		"2200 1234 5678 9019": "RU",
+		"6081123456789014":    "IN",
+		"6042011234567890":    "AR",
+		"5066990000000002":    "BR",
+		"9792123456789018":    "TR",
+		"5019717010103742":    "DK",
+		"8600123456789012":    "UZ",
+		"9860123456789015":    "UZ",
+	} {
+		card := Card{Number: number}
+		country, ok := card.IssuerCountry()
+		assert.True(ok, number)
+		assert.Equal(expected, country, number)
+	}
+
+	// International card schemes are issued in many countries
+	for _, number := range []string{
+		"4012888888881881", "5555555555554444", "3530111333300000", "6200000000000005", "22001", "abcdef1234567890",
+	} {
+		card := Card{Number: number}
+		country, ok := card.IssuerCountry()
+		assert.False(ok, number)
+		assert.Empty(country, number)
+	}
+}
+
+func TestParseIssuerCountries(t *testing.T) {
+	assert := assert.New(t)
+
+	ranges := parseIssuerCountries("# comment\n\n220000,220499,RU\ninvalid\n979200,abc,TR\n")
+	assert.Equal([]issuerCountryRange{{start: 220000, end: 220499, country: "RU"}}, ranges)
+	assert.NotEmpty(parseIssuerCountries(issuerCountriesCSV))
+}
@@ -1,35 +1,53 @@
 // Package creditcard is a module that performs credit card validation.
+//
+// The package is safe for concurrent use. Validating a card never changes the card or any shared state,
+// and card types can be registered with RegisterCardType, or BIN ranges loaded with LoadBINRanges, while
+// other goroutines validate cards, so the package can be used from HTTP handlers without external locking.
+// A single Card should not be changed while it is being validated.
 package creditcard
 
 import (
+	"errors"
 	"fmt"
-	"strconv"
+	"sort"
+	"strings"
 	"time"
+	"unicode"
 )
 
 // Card is a struct that contains a credit card type. This holds generic information about the credit card
 type Card struct {
-	// Type is an optional string with one of the supported card types. If the type is supplied, it will be validated that the type matches the number
+	// Type is an optional string with one of the supported card types. If the type is supplied, it will be validated that the type matches the number.
+	// Both the display name of a card type, like "American Express", and the name of its CardType constant, like "AmericanExpress", are accepted
 	Type string
 	// Number is the credit card number
 	Number string
 	// ExpiryMonth is the credit card expiration month
 	ExpiryMonth int
-	// ExpiryYear is the credit card expiration year
+	// ExpiryYear is the credit card expiration year. Two digit years, like 25, are expanded to a four digit year
 	ExpiryYear int
 	// CVV is the credit card CVV code
 	CVV string
+	// opts holds the optional configuration of the card
+	opts options
+	// validation is the validation result that was restored with UnmarshalBinary
+	validation *Validation
 }
 
 // Validation is the object returned by the validate method, which contains the validation result of the card
 type Validation struct {
-	// A pointer to the struct that was passed in for validation
+	// A pointer to a copy of the card that was validated. The Type of the copy is set to the detected card type if it was not given
 	Card *Card
+	// DetectedType is the card type determined from the card number, which is Unknown if the card type can't be determined.
+	// When type detection is disabled with WithTypeDetection, it's the given card type instead
+	DetectedType CardType
+	// TypeProvided is a boolean that indicates if the card type was supplied with the card, rather than detected from the card number
+	TypeProvided bool
 	// ValidCardNumber is a boolean that indicates if a credit card number is valid for a given credit card type if given and verifies that the credit card number passes the Luhn algorithm
 	ValidCardNumber bool
 	// ValidExpiryMonth is a boolean that indicates if a value is a valid credit card expiry month (the range is 1 to 12)
 	ValidExpiryMonth bool
-	// ValidExpiryYear is a boolean that indicates if a value is a valid credit card expiry year (the range is 1900 to 2200)
+	// ValidExpiryYear is a boolean that indicates if a value is a valid credit card expiry year (by default the range is the current year up to 20 years from now)
 	ValidExpiryYear bool
 	// ValidCVV is a boolean that indicates if a CVV is valid for a given credit card type. For example, American Express requires a four digit CVV, while Visa and Mastercard require a three digit CVV
 	ValidCVV bool
@@ -37,13 +55,21 @@ type Validation struct {
 	IsExpired bool
 	// Errors is an array of validation errors that might occur during validation
 	Errors []string
+	// errs contains the errors that occurred during validation, in the same order as Errors
+	errs []error
+	// Warnings is an array of advisory findings that don't make the card invalid: the card expires soon, the card
+	// number has a suspicious pattern, or the card number is a test number
+	Warnings []string
+	// warns contains the warnings that were found during validation, in the same order as Warnings
+	warns []error
 }
 
-type cardType int
+// CardType represents one of the supported credit card types
+type CardType int
 
 const (
 	// Unknown card type
-	Unknown cardType = iota
+	Unknown CardType = iota
 	// AmericanExpress  card type
 	AmericanExpress
 	// Aura card type
@@ -68,6 +94,8 @@ const (
 	Elo
 	// Hipercard card type
 	Hipercard
+	// Humo card type
+	Humo
 	// InstaPayment card type
 	InstaPayment
 	// InterPayment card type
@@ -78,6 +106,18 @@ const (
 	Maestro
 	// Mastercard card type
 	Mastercard
+	// Mir card type
+	Mir
+	// RuPay card type
+	RuPay
+	// Troy card type
+	Troy
+	// UATP card type
+	UATP
+	// UzCard card type
+	UzCard
+	// Verve card type
+	Verve
 	// Visa card type
 	Visa
 	// VisaElectron card type
@@ -98,92 +138,645 @@ var cardTypeNames = [...]string{
 	"Discover",
 	"Elo",
 	"Hipercard",
+	"Humo",
 	"InstaPayment",
 	"InterPayment",
 	"JCB",
 	"Maestro",
 	"Mastercard",
+	"Mir",
+	"RuPay",
+	"Troy",
+	"UATP",
+	"UzCard",
+	"Verve",
 	"Visa",
 	"Visa Electron",
 }
 
-type digits [6]int
+// cardTypeLengths contains the number lengths that are valid for each card type
+var cardTypeLengths = map[CardType][]int{
+	AmericanExpress:         {15},
+	Aura:                    {16},
+	Bankcard:                {16},
+	Cabal:                   {16},
+	ChinaUnionPay:           {16, 17, 18, 19},
+	Dankort:                 {16},
+	DinersClubCarteBlanche:  {15},
+	DinersClubEnroute:       {15},
+	DinersClubInternational: {14},
+	Discover:                {16, 17, 18, 19},
+	Elo:                     {16},
+	Hipercard:               {13, 16, 19},
+	Humo:                    {16},
+	InstaPayment:            {16},
+	InterPayment:            {16, 17, 18, 19},
+	JCB:                     {16, 17, 18, 19},
+	Maestro:                 {12, 13, 14, 15, 16, 17, 18, 19},
+	Mastercard:              {16},
+	Mir:                     {16},
+	RuPay:                   {16},
+	Troy:                    {16},
+	UATP:                    {15},
+	UzCard:                  {16},
+	Verve:                   {16, 19},
+	Visa:                    {13, 16, 19},
+	VisaElectron:            {16},
+}
+
+// cardTypeCVVLengths contains the CVV lengths that are valid for each card type. A length of 0 means the card type
+// doesn't require a CVV. Card types that are not in the list are expected to have a three digit CVV
+var cardTypeCVVLengths = map[CardType][]int{
+	AmericanExpress:         {4},
+	Aura:                    {3},
+	Bankcard:                {3},
+	Cabal:                   {3},
+	ChinaUnionPay:           {3},
+	Dankort:                 {3},
+	DinersClubCarteBlanche:  {3},
+	DinersClubEnroute:       {3},
+	DinersClubInternational: {3},
+	Discover:                {3},
+	Elo:                     {3},
+	Hipercard:               {3},
+	Humo:                    {3},
+	InstaPayment:            {3},
+	InterPayment:            {3},
+	JCB:                     {3},
+	Maestro:                 {0, 3},
+	Mastercard:              {3},
+	Mir:                     {3},
+	RuPay:                   {3},
+	Troy:                    {3},
+	UATP:                    {0, 3},
+	UzCard:                  {3},
+	Verve:                   {3},
+	Visa:                    {3},
+	VisaElectron:            {3},
+}
 
-// at returns the digits from the start to the given length
-func (d *digits) at(i int) int {
-	return d[i-1]
+// separators removes the characters that are commonly used to group the digits of a card number
+var separators = strings.NewReplacer(" ", "", "-", "")
+
+// String returns the display name of the card type
+func (t CardType) String() string {
+	if t >= 0 && int(t) < len(cardTypeNames) {
+		return cardTypeNames[t]
+	}
+	if name, ok := registeredCardTypeName(t); ok {
+		return name
+	}
+	return cardTypeNames[Unknown]
+}
+
+// SupportedTypes returns the built-in card types that can be determined from a card number, in alphabetical order.
+// Unknown and card types registered with RegisterCardType are not included
+func SupportedTypes() []CardType {
+	cardTypes := make([]CardType, 0, len(cardTypeNames)-1)
+	for i := range cardTypeNames {
+		if cardType := CardType(i); cardType != Unknown {
+			cardTypes = append(cardTypes, cardType)
+		}
+	}
+	return cardTypes
 }
 
-// name returns the string representation of the card
-func (cardType cardType) name() string {
-	return cardTypeNames[cardType]
+// SupportedTypeNames returns the display names of the card types returned by SupportedTypes, in the same order
+func SupportedTypeNames() []string {
+	cardTypes := SupportedTypes()
+	names := make([]string, len(cardTypes))
+	for i, cardType := range cardTypes {
+		names[i] = cardType.String()
+	}
+	return names
 }
 
 // Validate performs validation on the card. Apart from a copy of the card, it also returns
 // - ValidCardNumber is a boolean that indicates if a credit card number is valid for a given credit card type if given and verifies that the credit card number passes the Luhn algorithm
 // - ValidExpiryMonth is a boolean that indicates if a value is a valid credit card expiry month (the range is 1 to 12)
-// - ValidExpiryYear is a boolean that indicates if a value is a valid credit card expiry year (the range is 1900 to 2200)
+// - ValidExpiryYear is a boolean that indicates if a value is a valid credit card expiry year (by default the range is the current year up to 20 years from now)
 // - ValidCVV is a boolean that indicates if a CVV is valid for a given credit card type. For example, American Express requires a four digit CVV, while Visa and Mastercard require a three digit CVV
 // - IsExpired is a boolean that indicates if a credit card's expiration date has been reached
 // - Errors is an array of validation errors that might occur during validation
+// - Warnings is an array of advisory findings, like a card that expires soon, that don't make the card invalid
 func (c *Card) Validate() *Validation {
+	// Validate a copy, so the card that is passed in is never changed
+	card := *c
+	card.normalize()
 	val := &Validation{
-		Card:   c,
-		Errors: make([]string, 0),
+		Card:     &card,
+		Errors:   make([]string, 0),
+		Warnings: make([]string, 0),
 	}
 
+	switch {
+	case card.ExpiryMonth == 0 && card.ExpiryYear == 0:
+		// A missing expiry date is reported once, instead of as an invalid month, year, and expiry date
+		val.addError(ErrMissingExpiry)
+	case card.ExpiryMonth == 0 || card.ExpiryYear == 0:
+		card.validateIncompleteExpiry(val)
+	default:
+		card.validateExpiry(val)
+	}
+
+	// Without a card number, none of the checks that depend on the card number can pass
+	val.TypeProvided = len(card.Type) > 0
+	if card.number() == "" {
+		val.addError(ErrEmptyNumber)
+		return val
+	}
+
+	// The card type is resolved once, and used by all of the checks that depend on it
+	cardType, typeErr := card.resolveCardType()
+	val.DetectedType = cardType
+	if !val.TypeProvided {
+		if typeErr != nil {
+			val.addError(typeErr)
+		}
+		card.Type = cardType.String()
+	}
+
+	if card.opts.acceptedTypes != nil && !containsType(card.opts.acceptedTypes, cardType) {
+		val.addError(ErrTypeNotAccepted)
+	}
+
+	validCVV, err := card.validCVV(cardType)
+	if err != nil {
+		val.addError(err)
+	}
+	val.ValidCVV = validCVV
+
+	validNumber, err := card.validCardNumber(cardType, typeErr)
+	// Without a given card type, the unknown card type has been reported already
+	if err != nil && (val.TypeProvided || !errors.Is(err, ErrUnknownType)) {
+		val.addError(err)
+	}
+	val.ValidCardNumber = validNumber
+	if !val.ValidCardNumber {
+		val.addError(ErrInvalidNumber)
+	}
+
+	card.addWarnings(val)
+	return val
+}
+
+// Check validates the card and returns an error when the card can't be used, or nil when the card is valid. The error
+// joins all errors that occurred during validation, so each of them can be checked with errors.Is
+func (c *Card) Check() error {
+	val := c.Validate()
+	if val.Valid() {
+		return nil
+	}
+	return errors.Join(val.errs...)
+}
+
+// validateExpiry validates the expiry month and year of the card, and whether the card is expired
+func (c *Card) validateExpiry(val *Validation) {
 	val.ValidExpiryMonth = c.validExpiryMonth()
 	if !val.ValidExpiryMonth {
-		val.Errors = append(val.Errors, fmt.Sprintf("month '%d' is not a valid month", c.ExpiryMonth))
+		val.addError(newError(ErrInvalidMonth, "month '%d' is not a valid month", c.ExpiryMonth))
 	}
 
 	val.ValidExpiryYear = c.validExpiryYear()
 	if !val.ValidExpiryYear {
-		val.Errors = append(val.Errors, fmt.Sprintf("year '%d' is not a valid year", c.ExpiryYear))
+		val.addError(newError(ErrInvalidYear, "year '%d' is not a valid year", c.ExpiryYear))
 	}
 
 	val.IsExpired = c.isExpired()
 	if val.IsExpired {
-		val.Errors = append(val.Errors, "creditcard is expired")
+		val.addError(ErrExpired)
+	}
+}
+
+// expiringSoonWindow is the period before the end of the expiry date in which a card is reported as expiring soon
+const expiringSoonWindow = 30 * 24 * time.Hour
+
+// addWarnings adds the advisory findings for the card to the validation result
+func (c *Card) addWarnings(val *Validation) {
+	if val.ValidExpiryMonth && val.ValidExpiryYear && c.IsExpiringSoon(expiringSoonWindow) {
+		val.addWarning(ErrExpiringSoon)
 	}
+	if c.IsSuspiciousPattern() {
+		val.addWarning(ErrSuspiciousPattern)
+	}
+	if c.IsTestCard() {
+		val.addWarning(ErrTestCard)
+	}
+}
+
+// validateIncompleteExpiry validates an expiry date of which only the month or only the year is set. The missing
+// part is reported as an incomplete expiry date, instead of as an invalid month or year, while the part that is set
+// is still validated. Whether the card is expired can't be determined
+func (c *Card) validateIncompleteExpiry(val *Validation) {
+	val.addError(ErrIncompleteExpiry)
 
-	if len(c.Type) == 0 {
-		cardType, err := c.determineCardType()
-		if err != nil {
-			val.Errors = append(val.Errors, err.Error())
+	if c.ExpiryMonth != 0 {
+		val.ValidExpiryMonth = c.validExpiryMonth()
+		if !val.ValidExpiryMonth {
+			val.addError(newError(ErrInvalidMonth, "month '%d' is not a valid month", c.ExpiryMonth))
 		}
-		c.Type = cardType.name()
+		return
 	}
 
-	val.ValidCVV = c.matchCVV()
-	if !val.ValidCVV {
-		val.Errors = append(val.Errors, "cvv doesn't match")
+	val.ValidExpiryYear = c.validExpiryYear()
+	if !val.ValidExpiryYear {
+		val.addError(newError(ErrInvalidYear, "year '%d' is not a valid year", c.ExpiryYear))
 	}
+}
 
-	validNumber, err := c.validCardNumber()
-	if err != nil {
-		val.Errors = append(val.Errors, err.Error())
+// ValidateStrict performs validation on the card like Validate, but rejects the card entirely when the card type
+// can't be determined from the card number. In that case none of the other checks are performed, all of the flags
+// are false, and the only error is the unknown card type. Validate on the other hand still checks the expiry date
+// and CVV of a card with an unknown card type, so the card might look partially valid
+func (c *Card) ValidateStrict() *Validation {
+	if _, err := c.determineCardType(); err != nil {
+		card := *c
+		card.normalize()
+		val := &Validation{
+			Card:         &card,
+			TypeProvided: len(card.Type) > 0,
+			Errors:       make([]string, 0),
+			Warnings:     make([]string, 0),
+		}
+		val.addError(card.unknownTypeError(err))
+		return val
 	}
-	val.ValidCardNumber = validNumber
-	if !val.ValidCardNumber {
-		val.Errors = append(val.Errors, "card number is not valid")
+
+	return c.Validate()
+}
+
+// String returns a human-readable summary of the validation result, with the card type, masked card number,
+// expiry date, the result of each check, and the errors. The full card number and CVV are never included,
+// so the summary is safe to use in logs
+func (v *Validation) String() string {
+	var number, expiry string
+	if v.Card != nil {
+		number = v.Card.MaskNumber()
+		expiry = v.Card.ExpiryString()
 	}
 
-	return val
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Card type:    %s\n", v.DetectedType)
+	fmt.Fprintf(&sb, "Number:       %s\n", number)
+	fmt.Fprintf(&sb, "Expiry:       %s\n", expiry)
+	fmt.Fprintf(&sb, "Valid number: %s\n", passFail(v.ValidCardNumber))
+	fmt.Fprintf(&sb, "Valid month:  %s\n", passFail(v.ValidExpiryMonth))
+	fmt.Fprintf(&sb, "Valid year:   %s\n", passFail(v.ValidExpiryYear))
+	fmt.Fprintf(&sb, "Valid CVV:    %s\n", passFail(v.ValidCVV))
+	fmt.Fprintf(&sb, "Expired:      %t\n", v.IsExpired)
+	fmt.Fprintf(&sb, "Errors:       %s", strings.Join(v.Errors, "; "))
+	return sb.String()
 }
 
-// validCardNumber checks whether the given card type matches the actual expected card type and whether the number passes the luhn check
-func (c *Card) validCardNumber() (bool, error) {
+// passFail returns the result of a check as text
+func passFail(ok bool) string {
+	if ok {
+		return "pass"
+	}
+	return "fail"
+}
+
+// addError adds the error to the validation result
+func (v *Validation) addError(err error) {
+	v.errs = append(v.errs, err)
+	v.Errors = append(v.Errors, err.Error())
+}
+
+// addWarning adds the warning to the validation result
+func (v *Validation) addWarning(err error) {
+	v.warns = append(v.warns, err)
+	v.Warnings = append(v.Warnings, err.Error())
+}
+
+// HasWarning reports whether any of the warnings that were found during validation matches the target error,
+// using errors.Is, like ErrExpiringSoon
+func (v *Validation) HasWarning(target error) bool {
+	for _, err := range v.warns {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Valid reports whether the card can be used. This is the case when the card number, expiry month,
+// expiry year, and CVV are all valid, the card is not expired, and no errors occurred during validation
+func (v *Validation) Valid() bool {
+	return v.ValidCardNumber && v.ValidExpiryMonth && v.ValidExpiryYear && v.ValidCVV &&
+		!v.IsExpired && len(v.Errors) == 0
+}
+
+// Identifiers of the checks performed by Validate, as returned by FailedChecks
+const (
+	CheckCardNumber  = "card_number"
+	CheckExpiryMonth = "expiry_month"
+	CheckExpiryYear  = "expiry_year"
+	CheckCVV         = "cvv"
+	CheckExpired     = "expired"
+)
+
+// FailedChecks returns the identifiers of the checks that did not pass, in the order in which they are listed
+// in the Validation. The identifiers are stable, so they can be used to show errors next to the right input
+func (v *Validation) FailedChecks() []string {
+	checks := make([]string, 0)
+	if !v.ValidCardNumber {
+		checks = append(checks, CheckCardNumber)
+	}
+	if !v.ValidExpiryMonth {
+		checks = append(checks, CheckExpiryMonth)
+	}
+	if !v.ValidExpiryYear {
+		checks = append(checks, CheckExpiryYear)
+	}
+	if !v.ValidCVV {
+		checks = append(checks, CheckCVV)
+	}
+	if v.IsExpired {
+		checks = append(checks, CheckExpired)
+	}
+	return checks
+}
+
+// HasError reports whether any of the errors that occurred during validation matches the target error,
+// using errors.Is. This allows checking for specific failures, like ErrExpired, without comparing messages
+func (v *Validation) HasError(target error) bool {
+	for _, err := range v.errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse creates a card from the card number. Spaces and dashes are removed from the number, after which it is
+// checked that the number only contains digits, that the card type can be determined, and that the length of the
+// number is valid for the card type. The first problem that is found is returned as error. The returned card has
+// the normalized number and the detected type, so only the expiry date and CVV have to be added
+func Parse(number string) (*Card, error) {
+	c := &Card{
+		Number: separators.Replace(number),
+	}
+
+	if err := checkDigits(c.Number); err != nil {
+		return nil, err
+	}
+
 	cardType, err := c.determineCardType()
 	if err != nil {
+		return nil, err
+	}
+
+	if !validLength(cardType, len(c.Number)) {
+		return nil, newError(ErrInvalidLength, "length %d invalid for %s", len(c.Number), cardType)
+	}
+
+	c.Type = cardType.String()
+	return c, nil
+}
+
+// SetNumber replaces the card number with the number without spaces and dashes, and clears the card type. The card
+// type that was set for the previous number, for example by Parse, would no longer match, so the card type is
+// determined from the new number when the card is validated
+func (c *Card) SetNumber(number string) {
+	c.Number = separators.Replace(strings.TrimSpace(number))
+	c.Type = ""
+}
+
+// Brand returns the name of the card type based on the card number, without performing any other validation
+func (c *Card) Brand() (string, error) {
+	cardType, err := c.DetectType()
+	if err != nil {
+		return "", err
+	}
+	return cardType.String(), nil
+}
+
+// DetectType returns the card type based on the card number, without performing any other validation.
+// Unlike the Type field, the returned CardType can be compared against the exported constants
+func (c *Card) DetectType() (CardType, error) {
+	return c.determineCardType()
+}
+
+// IsAcceptedBy reports whether the card type determined from the card number is one of the allowed card types
+func (c *Card) IsAcceptedBy(allowed []CardType) bool {
+	cardType, err := c.determineCardType()
+	return err == nil && containsType(allowed, cardType)
+}
+
+// processingNetworks maps card types that are processed on the network of another card type to that card type
+var processingNetworks = map[CardType]CardType{
+	DinersClubCarteBlanche:  Discover,
+	DinersClubInternational: Discover,
+	Maestro:                 Mastercard,
+	VisaElectron:            Visa,
+}
+
+// ProcessingNetwork returns the card type of the network that processes the card when it's acquired, which can
+// differ from the brand that issued the card. Diners Club cards, for example, are processed on the Discover network
+// in many regions, and Maestro cards on the Mastercard network. Other card types are processed on their own network
+func (c *Card) ProcessingNetwork() CardType {
+	cardType, _ := c.determineCardType()
+	if network, ok := processingNetworks[cardType]; ok {
+		return network
+	}
+	return cardType
+}
+
+// VerifyType compares the given card type against the card type that is determined from the card number. It
+// returns the given card type, the name of the determined card type, and whether they match. A card without
+// a given card type always matches, and the name of an unknown card type is "Unknown Card"
+func (c *Card) VerifyType() (expected string, actual string, ok bool) {
+	expected = strings.TrimSpace(c.Type)
+	cardType, err := c.determineCardType()
+	actual = cardType.String()
+	if expected == "" {
+		return expected, actual, true
+	}
+	return expected, actual, err == nil && matchesType(expected, cardType)
+}
+
+// SameAs reports whether both cards represent the same card, with the same card number and expiry date. Spaces
+// and dashes in the card numbers are ignored, two digit expiry years are expanded, and the CVV is not compared
+func (c *Card) SameAs(other *Card) bool {
+	if other == nil {
+		return false
+	}
+	return c.number() == other.number() && c.ExpiryMonth == other.ExpiryMonth && c.expiryYear() == other.expiryYear()
+}
+
+// validCardNumber checks whether the given card type matches the actual expected card type and whether the number
+// passes the luhn check. The card type and the error are the result of resolveCardType
+func (c *Card) validCardNumber(cardType CardType, typeErr error) (bool, error) {
+	number := c.number()
+	if err := checkDigits(number); err != nil {
+		return false, err
+	}
+
+	if typeErr != nil {
+		return false, c.unknownTypeError(typeErr)
+	}
+
+	if !c.opts.skipTypeDetection && !matchesType(c.Type, cardType) {
+		return false, newError(ErrTypeMismatch, "given card type '%s' doesn't match determined card type '%s'", c.Type, cardType)
+	}
+
+	if !validLength(cardType, len(number)) {
+		return false, newError(ErrInvalidLength, "length %d invalid for %s", len(number), cardType)
+	}
+
+	if err := checkLuhn(number, cardType); err != nil {
 		return false, err
 	}
+	return true, nil
+}
+
+// unknownTypeError returns the error for a card number whose card type can't be determined. When a card type is
+// given, the error mentions it, so a given card type that doesn't match an unknown number is reported the same way
+// for every number, instead of as a type mismatch
+func (c *Card) unknownTypeError(err error) error {
+	if !errors.Is(err, ErrUnknownType) || c.Type == "" {
+		return err
+	}
+	return newError(ErrUnknownType, "unknown creditcard type, the card number doesn't match given card type '%s'", c.Type)
+}
+
+// resolveCardType returns the card type that is used for validation. This is the card type determined from the
+// card number, unless type detection is disabled and a card type is given, in which case the given card type is
+// trusted. Given card types that aren't known result in Unknown, without an error
+func (c *Card) resolveCardType() (CardType, error) {
+	if c.opts.skipTypeDetection && strings.TrimSpace(c.Type) != "" {
+		return cardTypeNamed(c.Type), nil
+	}
+	return c.determineCardType()
+}
+
+// cardTypeNamed returns the built-in or registered card type that the name refers to, or Unknown if there is none
+func cardTypeNamed(name string) CardType {
+	for i, typeName := range cardTypeNames {
+		if CardType(i) != Unknown && sameTypeName(name, typeName) {
+			return CardType(i)
+		}
+	}
+	if cardType, ok := registeredCardTypeNamed(name); ok {
+		return cardType
+	}
+	return Unknown
+}
+
+// matchesType checks whether the name refers to the card type, ignoring case and whitespace. This accepts
+// both the display name, like "American Express", and the name of the constant, like "AmericanExpress"
+func matchesType(name string, cardType CardType) bool {
+	return sameTypeName(name, cardType.String())
+}
+
+// sameTypeName checks whether both names refer to the same card type, ignoring case and whitespace
+func sameTypeName(a, b string) bool {
+	// Most names are spelled the same, which can be checked without removing the whitespace first
+	if strings.EqualFold(a, b) {
+		return true
+	}
+	return strings.EqualFold(removeWhitespace(a), removeWhitespace(b))
+}
+
+// removeWhitespace removes all whitespace from the string
+func removeWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
 
-	if cardType.name() != c.Type {
-		return false, fmt.Errorf("given card type doesn't match determined card type")
+// ExpectedLengths returns the numbers of digits that are valid for the card type of the card number, for example to
+// limit the length of an input field. When the card type is determined from the card number, its lengths are
+// returned. This also works while the number is being typed, in which case the lengths are those of the card types
+// the number can still become. A length is only returned when the card type that would be determined for a number
+// of that length accepts it, like 14 for Diners Club International and 15 for Diners Club Carte Blanche for 3000.
+// Nil is returned when the card type or its lengths aren't known
+func (c *Card) ExpectedLengths() []int {
+	number := c.number()
+	if cardType, err := c.determineCardType(); err == nil && contains(cardTypeLengths[cardType], len(number)) {
+		return append([]int(nil), cardTypeLengths[cardType]...)
 	}
 
-	return c.validateLuhn(), nil
+	rules := append(loadedRuleList(), cardTypeRules.all...)
+	var lengths []int
+	for length := 1; length <= maxNumberLength; length++ {
+		for i := range rules {
+			rule := &rules[i]
+			if !rule.validLength(length) {
+				continue
+			}
+
+			// A rule that matches the digits so far wins over all less specific rules, while a rule with a longer
+			// prefix only wins when the next digits match it, so the less specific rules still have a chance
+			matched := rule.matches(number, true)
+			if !matched && !rule.matchesStart(number) {
+				continue
+			}
+			if contains(cardTypeLengths[rule.cardType], length) {
+				lengths = append(lengths, length)
+				break
+			}
+			if matched {
+				break
+			}
+		}
+	}
+	return lengths
+}
+
+// validLength checks whether the length of the number is valid for the card type. Any length
+// is accepted for card types without known lengths, like registered card types
+func validLength(cardType CardType, length int) bool {
+	lengths, ok := cardTypeLengths[cardType]
+	if !ok {
+		return true
+	}
+	return contains(lengths, length)
+}
+
+// contains checks whether the list of lengths contains the length
+func contains(lengths []int, length int) bool {
+	for _, l := range lengths {
+		if l == length {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize removes the whitespace that commonly surrounds values entered in forms from the CVV and type
+func (c *Card) normalize() {
+	c.CVV = strings.TrimSpace(c.CVV)
+	c.Type = strings.TrimSpace(c.Type)
+	c.ExpiryYear = c.expiryYear()
+}
+
+// number returns the card number without any spaces or dashes
+func (c *Card) number() string {
+	return separators.Replace(c.Number)
+}
+
+// checkDigits checks, rune by rune, whether the number only contains the ASCII digits 0 to 9. Other Unicode
+// digits, like full-width digits, are reported separately since they are easily mistaken for ASCII digits
+func checkDigits(number string) error {
+	for _, r := range number {
+		switch {
+		case r >= '0' && r <= '9':
+		case unicode.IsDigit(r):
+			return ErrNonASCIIDigit
+		default:
+			return ErrNonDigit
+		}
+	}
+	return nil
+}
+
+// isDigits checks whether the string only contains the digits 0 to 9
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
 }
 
 // validExpiryMonth validates whether the expiry month is a proper month (between 1 and 12)
@@ -194,168 +787,405 @@ func (c *Card) validExpiryMonth() bool {
 	return true
 }
 
-// validExpiryYear validates whether the expiry year is a valid year (between 1900 and 2200)
+// validExpiryYear validates whether the expiry year is within the accepted range of expiry years
 func (c *Card) validExpiryYear() bool {
-	if c.ExpiryYear < 1900 || c.ExpiryYear > 2200 {
+	min, max := c.expiryYearRange()
+	if year := c.expiryYear(); year < min || year > max {
 		return false
 	}
 	return true
 }
 
-// isExpired is a boolean that indicates whether the card is expired or not
+// expiryYearRange returns the range of accepted expiry years, which defaults to the current year up to 20 years from now.
+// The grace period is taken into account, so a card that is still in its grace period in January has a valid year
+func (c *Card) expiryYearRange() (int, int) {
+	if c.opts.expiryYearRange != nil {
+		return c.opts.expiryYearRange[0], c.opts.expiryYearRange[1]
+	}
+	now := c.now()
+	return now.Add(-c.opts.expiryGrace).Year(), now.Year() + defaultExpiryYears
+}
+
+// isExpired is a boolean that indicates whether the card is expired or not. A card is valid
+// up to and including the last day of the expiry month, extended by the grace period if there is one.
+// This only depends on the expiry date, so a year outside of the accepted range doesn't make a card expired
 func (c *Card) isExpired() bool {
-	if !c.validExpiryMonth() || !c.validExpiryYear() {
+	expiry, err := c.ExpiryDate()
+	if err != nil {
 		return true
 	}
 
-	date := fmt.Sprintf("%d-%d-01", c.ExpiryYear, c.ExpiryMonth)
-	parsetime, _ := time.Parse("2006-1-02", date)
+	return c.now().After(expiry.Add(c.opts.expiryGrace))
+}
 
-	return parsetime.Before(time.Now())
+// expiryEnd returns the first moment after the expiry month, which is the moment the card expires
+func (c *Card) expiryEnd() time.Time {
+	return time.Date(c.expiryYear(), time.Month(c.ExpiryMonth)+1, 1, 0, 0, 0, 0, time.UTC)
 }
 
-// matchCVV checks whether the CVV length matches the expected length
-func (c *Card) matchCVV() bool {
-	switch c.Type {
-	case "American Express":
-		return len(c.CVV) == 4
-	default:
-		return len(c.CVV) == 3
+// now returns the current time according to the clock of the card
+func (c *Card) now() time.Time {
+	if c.opts.now == nil {
+		return time.Now()
+	}
+	return c.opts.now()
+}
+
+// validCVV checks whether the CVV only contains digits and whether it has the expected length for the card type
+// used for validation. A missing CVV is accepted when the CVV is not required
+func (c *Card) validCVV(cardType CardType) (bool, error) {
+	if c.CVV == "" && c.opts.cvvOptional {
+		return true, nil
+	}
+
+	if !isDigits(c.CVV) {
+		return false, ErrCVVNotNumeric
+	}
+
+	if !c.matchCVV(cardType) {
+		return false, ErrCVVMismatch
+	}
+
+	return true, nil
+}
+
+// matchCVV checks whether the CVV length matches the expected length for the card type used for validation, unless
+// the expected length is set with WithExpectedCVVLength
+func (c *Card) matchCVV(cardType CardType) bool {
+	if c.opts.expectedCVVLength > 0 {
+		return c.CVVLengthMatches(c.opts.expectedCVVLength)
 	}
+	return contains(cvvLengths(cardType), len(c.CVV))
+}
+
+// CVVLengthMatches reports whether the CVV, ignoring surrounding whitespace, has the expected length
+func (c *Card) CVVLengthMatches(expected int) bool {
+	return len(strings.TrimSpace(c.CVV)) == expected
+}
+
+// RequiresCVV reports whether a CVV is required for the card type determined from the card number.
+// Some card types, like UATP and certain Maestro cards, don't have a CVV
+func (c *Card) RequiresCVV() bool {
+	cardType, _ := c.determineCardType()
+	return !contains(cvvLengths(cardType), 0)
 }
 
-// determineCardType determines which card type the credit card has
-func (c *Card) determineCardType() (cardType, error) {
-	ccLen := len(c.Number)
-	ccDigits := digits{}
+// cvvLengths returns the CVV lengths that are valid for the card type
+func cvvLengths(cardType CardType) []int {
+	lengths, ok := cardTypeCVVLengths[cardType]
+	if !ok {
+		return []int{3}
+	}
+	return lengths
+}
+
+// cardTypePrefixes lists the prefixes of the card numbers of each card type. A prefix is either a single prefix, like
+// "4011", or a range of prefixes with the same number of digits, like "650031-650033". Card types that only use
+// some of the lengths of the card numbers starting with a prefix have a minimum and maximum length, where 0 means
+// there is no limit. Some prefixes overlap, in which case the longest prefix wins, see cardTypeRules
+var cardTypePrefixes = []struct {
+	cardType             CardType
+	minLength, maxLength int
+	prefixes             []string
+}{
+	{Elo, 0, 0, []string{
+		"4011", "431274", "438935", "451416", "457393", "4576", "457631", "457632", "504175", "627780", "636297",
+		"636368", "636369", "506699-506778", "509000-509999", "650031-650033", "650035-650051", "650405-650439",
+		"650485-650538", "650541-650598", "650700-650718", "650720-650727", "650901-650920", "651652-651679",
+		"655000-655019", "655021-655058",
+	}},
+	{Verve, 0, 0, []string{"506099-506198", "650002-650027"}},
+	{Cabal, 0, 0, []string{"604201-604219"}},
+	{Hipercard, 0, 0, []string{
+		"384100", "384140", "384160", "606282", "637095", "637568", "637599", "637609", "637612",
+	}},
+	{AmericanExpress, 0, 0, []string{"34", "37"}},
+	{Bankcard, 0, 0, []string{"5610", "560221-560225"}},
+	// Discover's ranges that are co-branded with China UnionPay are part of the 62 prefix
+	{Discover, 0, 0, []string{"622126-622925", "624000-626999", "628200-628899"}},
+	{ChinaUnionPay, 0, 0, []string{"62"}},
+	{DinersClubCarteBlanche, 15, 15, []string{"300-305"}},
+	{DinersClubEnroute, 0, 0, []string{"2014", "2149"}},
+	{DinersClubInternational, 0, 14, []string{"300-305", "309", "36", "38", "39"}},
+	// RuPay shares the 60 and 65 prefixes with Discover, but Discover's 6011 prefix is longer
+	{RuPay, 0, 0, []string{"60", "6521", "6522", "81", "82"}},
+	{Discover, 0, 0, []string{"6011", "644-649", "65"}},
+	{InterPayment, 16, 19, []string{"636"}},
+	{InstaPayment, 16, 16, []string{"637-639"}},
+	{Maestro, 0, 0, []string{
+		"5018", "5020", "5038", "5612", "5893", "6304", "6759", "6761", "6762", "6763", "0604", "6390",
+	}},
+	{Dankort, 0, 0, []string{"5019"}},
+	{Mir, 0, 0, []string{"2200-2204"}},
+	{Mastercard, 0, 0, []string{"51-55", "222100-272099"}},
+	{JCB, 16, 19, []string{"3528-3589"}},
+	{Aura, 0, 0, []string{"50"}},
+	{Troy, 0, 0, []string{"9792"}},
+	{UzCard, 16, 16, []string{"8600"}},
+	{Humo, 16, 16, []string{"9860"}},
+	{VisaElectron, 0, 0, []string{"4026", "417500", "4405", "4508", "4844", "4913", "4917"}},
+	{UATP, 15, 15, []string{"1"}},
+	{Visa, 0, 0, []string{"4"}},
+}
 
-	// Take the first 6 digits of the card number,
-	// convert to a integer to allow easy comparison after
-	for i := 0; i < 6; i++ {
-		if i < ccLen {
-			ccDigits[i], _ = strconv.Atoi(c.Number[:i+1])
+// cardTypeRule describes a range of prefixes of card numbers that belong to a card type
+type cardTypeRule struct {
+	// cardType is the card type that is recognized by the rule
+	cardType CardType
+	// from and to are the first and last prefix of the range, which have the same number of digits
+	from, to string
+	// minLength and maxLength limit the lengths of the card numbers that match, where 0 means there is no limit
+	minLength, maxLength int
+}
+
+// cardTypeRuleIndex groups the rules by the first digits of the numbers they can match, so only a few rules
+// are checked for each number. Each group is ordered by specificity, like the rules themselves
+type cardTypeRuleIndex struct {
+	// all contains all rules, ordered by specificity
+	all []cardTypeRule
+	// byDigit contains the rules with a prefix of a single digit, for numbers of a single digit
+	byDigit [10][]cardTypeRule
+	// byTwoDigits contains the rules that can match numbers starting with the two digits
+	byTwoDigits [100][]cardTypeRule
+}
+
+// cardTypeRules contains the rules to recognize each of the card types, ordered by specificity. Rules with longer
+// prefixes come first, so a specific prefix, like Discover's 6011, always wins over a broader prefix that
+// includes it, like RuPay's 60. Rules with prefixes of the same length keep the order of cardTypePrefixes
+var cardTypeRules = newCardTypeRules()
+
+// newCardTypeRules creates the rules from cardTypePrefixes, orders them by specificity, and indexes them
+func newCardTypeRules() *cardTypeRuleIndex {
+	rules := make([]cardTypeRule, 0)
+	for _, t := range cardTypePrefixes {
+		for _, prefix := range t.prefixes {
+			from, to := prefix, prefix
+			if i := strings.IndexByte(prefix, '-'); i >= 0 {
+				from, to = prefix[:i], prefix[i+1:]
+			}
+			rules = append(rules, cardTypeRule{
+				cardType: t.cardType, from: from, to: to, minLength: t.minLength, maxLength: t.maxLength,
+			})
 		}
 	}
 
-	// The switch below compares the first digits, and the security code size,
-	// for return a company for each bin range using the card number
-	switch {
-	case ccDigits.at(4) == 4011 || ccDigits.at(6) == 431274 || ccDigits.at(6) == 438935 ||
-		ccDigits.at(6) == 451416 || ccDigits.at(6) == 457393 || ccDigits.at(4) == 4576 ||
-		ccDigits.at(6) == 457631 || ccDigits.at(6) == 457632 || ccDigits.at(6) == 504175 ||
-		ccDigits.at(6) == 627780 || ccDigits.at(6) == 636297 || ccDigits.at(6) == 636368 ||
-		ccDigits.at(6) == 636369 || (ccDigits.at(6) >= 506699 && ccDigits.at(6) <= 506778) ||
-		(ccDigits.at(6) >= 509000 && ccDigits.at(6) <= 509999) ||
-		(ccDigits.at(6) >= 650031 && ccDigits.at(6) <= 650051) ||
-		(ccDigits.at(6) >= 650035 && ccDigits.at(6) <= 650033) ||
-		(ccDigits.at(6) >= 650405 && ccDigits.at(6) <= 650439) ||
-		(ccDigits.at(6) >= 650485 && ccDigits.at(6) <= 650538) ||
-		(ccDigits.at(6) >= 650541 && ccDigits.at(6) <= 650598) ||
-		(ccDigits.at(6) >= 650700 && ccDigits.at(6) <= 650718) ||
-		(ccDigits.at(6) >= 650720 && ccDigits.at(6) <= 650727) ||
-		(ccDigits.at(6) >= 650901 && ccDigits.at(6) <= 650920) ||
-		(ccDigits.at(6) >= 651652 && ccDigits.at(6) <= 651679) ||
-		(ccDigits.at(6) >= 655000 && ccDigits.at(6) <= 655019) ||
-		(ccDigits.at(6) >= 655021 && ccDigits.at(6) <= 655021):
-		return Elo, nil
-
-	case ccDigits.at(6) >= 604201 && ccDigits.at(6) <= 604219:
-		return Cabal, nil
-
-	case ccDigits.at(6) == 384100 || ccDigits.at(6) == 384140 || ccDigits.at(6) == 384160 ||
-		ccDigits.at(6) == 606282 || ccDigits.at(6) == 637095 || ccDigits.at(4) == 637568 ||
-		ccDigits.at(4) == 637599 || ccDigits.at(4) == 637609 || ccDigits.at(4) == 637612:
-		return Hipercard, nil
-
-	case ccDigits.at(2) == 34 || ccDigits.at(2) == 37:
-		return AmericanExpress, nil
-
-	case ccDigits.at(4) == 5610 || (ccDigits.at(6) >= 560221 && ccDigits.at(6) <= 560225):
-		return Bankcard, nil
-
-	case ccDigits.at(2) == 62:
-		return ChinaUnionPay, nil
-
-	case ccDigits.at(3) >= 300 && ccDigits.at(3) <= 305 && ccLen == 15:
-		return DinersClubCarteBlanche, nil
-
-	case ccDigits.at(4) == 2014 || ccDigits.at(4) == 2149:
-		return DinersClubEnroute, nil
-
-	case ((ccDigits.at(3) >= 300 && ccDigits.at(3) <= 305) || ccDigits.at(3) == 309 ||
-		ccDigits.at(2) == 36 || ccDigits.at(2) == 38 || ccDigits.at(2) == 39) && ccLen <= 14:
-		return DinersClubInternational, nil
-
-	case ccDigits.at(4) == 6011 || (ccDigits.at(6) >= 622126 && ccDigits.at(6) <= 622925) ||
-		(ccDigits.at(3) >= 644 && ccDigits.at(3) <= 649) || ccDigits.at(2) == 65:
-		return Discover, nil
-
-	case ccDigits.at(3) == 636 && ccLen >= 16 && ccLen <= 19:
-		return InterPayment, nil
-
-	case ccDigits.at(3) >= 637 && ccDigits.at(3) <= 639 && ccLen == 16:
-		return InstaPayment, nil
-
-	case ccDigits.at(4) == 5018 || ccDigits.at(4) == 5020 || ccDigits.at(4) == 5038 ||
-		ccDigits.at(4) == 5612 || ccDigits.at(4) == 5893 || ccDigits.at(4) == 6304 ||
-		ccDigits.at(4) == 6759 || ccDigits.at(4) == 6761 || ccDigits.at(4) == 6762 ||
-		ccDigits.at(4) == 6763 || c.Number[:3] == "0604" || ccDigits.at(4) == 6390:
-		return Maestro, nil
-
-	case ccDigits.at(4) == 5019:
-		return Dankort, nil
-
-	case ccDigits.at(2) >= 51 && ccDigits.at(2) <= 55:
-		return Mastercard, nil
-
-	case ccDigits.at(2) == 35:
-		return JCB, nil
-
-	case ccDigits.at(2) == 50:
-		return Aura, nil
-
-	case ccDigits.at(4) == 4026 || ccDigits.at(6) == 417500 || ccDigits.at(4) == 4405 ||
-		ccDigits.at(4) == 4508 || ccDigits.at(4) == 4844 || ccDigits.at(4) == 4913 ||
-		ccDigits.at(4) == 4917:
-		return VisaElectron, nil
-
-	case ccDigits.at(1) == 4:
-		return Visa, nil
+	sort.SliceStable(rules, func(i, j int) bool {
+		return len(rules[i].from) > len(rules[j].from)
+	})
 
+	index := &cardTypeRuleIndex{all: rules}
+	for _, rule := range rules {
+		// A rule can match all numbers of which the first two digits are in the range of its prefixes,
+		// where a prefix of a single digit matches any second digit
+		var first, last int
+		if len(rule.from) == 1 {
+			digit := rule.from[0] - '0'
+			index.byDigit[digit] = append(index.byDigit[digit], rule)
+			first, last = int(digit)*10, int(rule.to[0]-'0')*10+9
+		} else {
+			first, last = twoDigits(rule.from), twoDigits(rule.to)
+		}
+		for i := first; i <= last; i++ {
+			index.byTwoDigits[i] = append(index.byTwoDigits[i], rule)
+		}
+	}
+	return index
+}
+
+// builtInRules returns the built-in rules that can match the number, based on its first two digits
+func builtInRules(number string, digits int) []cardTypeRule {
+	switch {
+	case digits >= 2:
+		return cardTypeRules.byTwoDigits[twoDigits(number)]
+	case digits == 1:
+		return cardTypeRules.byDigit[number[0]-'0']
 	default:
-		return 0, fmt.Errorf("unknown creditcard type")
+		return nil
+	}
+}
+
+// twoDigits returns the value of the first two digits of the number
+func twoDigits(number string) int {
+	return int(number[0]-'0')*10 + int(number[1]-'0')
+}
+
+// matches reports whether the number starts with a prefix in the range of the rule and has a valid length. When
+// prefixOnly is set, the length of the number is not checked, so a partial number can be matched
+func (r *cardTypeRule) matches(number string, prefixOnly bool) bool {
+	return r.matchesDigits(number, leadingDigits(number), prefixOnly)
+}
+
+// matchesDigits is like matches, for a number that starts with the given count of ASCII digits, so the digits
+// don't have to be checked for every rule. Prefixes of the same length compare like numbers, so a plain string
+// comparison is enough
+func (r *cardTypeRule) matchesDigits(number string, digits int, prefixOnly bool) bool {
+	if digits < len(r.from) {
+		return false
+	}
+	if !prefixOnly && !r.validLength(len(number)) {
+		return false
+	}
+
+	prefix := number[:len(r.from)]
+	return r.from <= prefix && prefix <= r.to
+}
+
+// matchesStart reports whether the number is shorter than the prefixes of the rule, and is the start of one of them
+func (r *cardTypeRule) matchesStart(number string) bool {
+	if number == "" || len(number) >= len(r.from) || leadingDigits(number) < len(number) {
+		return false
+	}
+	return r.from[:len(number)] <= number && number <= r.to[:len(number)]
+}
+
+// leadingDigits returns the number of ASCII digits at the start of the number
+func leadingDigits(number string) int {
+	for i := 0; i < len(number); i++ {
+		if number[i] < '0' || number[i] > '9' {
+			return i
+		}
+	}
+	return len(number)
+}
+
+// validLength reports whether card numbers of the length can match the rule
+func (r *cardTypeRule) validLength(length int) bool {
+	return (r.minLength <= 0 || length >= r.minLength) && (r.maxLength <= 0 || length <= r.maxLength)
+}
+
+// determineCardType determines which card type the credit card has, based on the most specific rule that matches
+// the number. Rules loaded with LoadBINRanges come before the built-in rules, and registered card types are only
+// considered when none of the rules match
+func (c *Card) determineCardType() (CardType, error) {
+	return detectCardType(c.number(), false)
+}
+
+// detectCardType determines the card type of the number, like determineCardType. When prefixOnly is set, the length
+// of the number is not taken into account, so the card type can be determined from the first digits only
+func detectCardType(number string, prefixOnly bool) (CardType, error) {
+	if cardType, ok := loadedCardType(number, prefixOnly); ok {
+		return cardType, nil
+	}
+	digits := leadingDigits(number)
+	rules := builtInRules(number, digits)
+	for i := range rules {
+		if rules[i].matchesDigits(number, digits, prefixOnly) {
+			return rules[i].cardType, nil
+		}
+	}
+
+	if cardType, ok := registeredCardType(number); ok {
+		return cardType, nil
+	}
+	return Unknown, ErrUnknownType
+}
+
+// DetectBrandFromBIN determines the card type from the first digits of a card number, like the BIN, for example to
+// show the brand while the number is being typed. Unlike DetectType, the length of the number is not taken into
+// account, so card types that only differ in the length of their numbers, like Diners Club Carte Blanche and Diners
+// Club International, can't be told apart. In that case the first card type that uses the prefix is returned
+func DetectBrandFromBIN(bin string) (CardType, error) {
+	number := separators.Replace(strings.TrimSpace(bin))
+	if number == "" {
+		return Unknown, ErrEmptyNumber
+	}
+	if err := checkDigits(number); err != nil {
+		return Unknown, err
+	}
+	return detectCardType(number, true)
+}
+
+// PossibleTypes returns all card types whose rules match the card number, from the most specific rule to the least
+// specific one, followed by any matching registered card types. Some cards are co-badged and belong to more than
+// one card type, for example Elo and Visa, while DetectType only returns the card type of the most specific rule.
+// Card types of rules loaded with LoadBINRanges come first
+func (c *Card) PossibleTypes() []CardType {
+	number := c.number()
+
+	cardTypes := loadedCardTypes(make([]CardType, 0), number)
+	digits := leadingDigits(number)
+	rules := builtInRules(number, digits)
+	for i := range rules {
+		rule := &rules[i]
+		if rule.matchesDigits(number, digits, false) && !containsType(cardTypes, rule.cardType) {
+			cardTypes = append(cardTypes, rule.cardType)
+		}
+	}
+
+	return append(cardTypes, registeredCardTypes(number)...)
+}
+
+// containsType checks whether the card type is part of the card types
+func containsType(cardTypes []CardType, cardType CardType) bool {
+	for _, t := range cardTypes {
+		if t == cardType {
+			return true
+		}
+	}
+	return false
+}
+
+// minNumberLength and maxNumberLength are the shortest and longest card numbers that can pass the Luhn check,
+// unless the card type allows shorter card numbers
+const (
+	minNumberLength = 13
+	maxNumberLength = 19
+)
+
+// minNumberLength returns the length of the shortest card number that can pass the Luhn check, for the card type
+// used for validation
+func (c *Card) minNumberLength() int {
+	cardType, _ := c.resolveCardType()
+	return minLengthFor(cardType)
+}
+
+// minLengthFor returns the length of the shortest card number of the card type that can pass the Luhn check. This is
+// 13 digits, unless the card type has shorter card numbers
+func minLengthFor(cardType CardType) int {
+	minLength := minNumberLength
+	for _, length := range cardTypeLengths[cardType] {
+		if length < minLength {
+			minLength = length
+		}
 	}
+	return minLength
 }
 
 // http://en.wikipedia.org/wiki/Luhn_algorithm
 // validateLuhn will check the credit card's number against the Luhn algorithm
 func (c *Card) validateLuhn() bool {
-	var sum int
-	var alternate bool
+	return c.CheckLuhn() == nil
+}
 
-	// Gets the Card number length
-	numberLen := len(c.Number)
+// CheckLuhn checks the card number against the Luhn algorithm and reports why it fails. ErrLengthOutOfRange is
+// returned for numbers that are shorter than 13 or longer than 19 digits, and ErrChecksumFailed is returned for
+// numbers with a check digit that doesn't match. Both can be checked with errors.Is. Card types with shorter
+// numbers, like Maestro with numbers of 12 digits, lower the minimum length
+func (c *Card) CheckLuhn() error {
+	number := c.number()
 
-	// For numbers that is lower than 13 and
-	// bigger than 19, must return as false
-	if numberLen < 13 || numberLen > 19 {
-		return false
+	// The card type only matters for numbers that are shorter than the usual minimum length
+	cardType := Unknown
+	if len(number) < minNumberLength {
+		cardType, _ = c.resolveCardType()
 	}
+	return checkLuhn(number, cardType)
+}
 
-	// Parse all numbers of the card into a for loop
-	for i := numberLen - 1; i > -1; i-- {
-		// Takes the mod, converting the current number in integer
-		mod, _ := strconv.Atoi(string(c.Number[i]))
-		if alternate {
-			mod *= 2
-			if mod > 9 {
-				mod = (mod % 10) + 1
-			}
-		}
+// checkLuhn checks the number against the Luhn algorithm, like CheckLuhn, with the minimum length of the card type
+func checkLuhn(number string, cardType CardType) error {
+	// Gets the Card number length
+	numberLen := len(number)
 
-		alternate = !alternate
-		sum += mod
+	// For numbers that is lower than the minimum and
+	// bigger than 19, must return as false
+	minLength := minLengthFor(cardType)
+	if numberLen < minLength || numberLen > maxNumberLength {
+		return newError(ErrLengthOutOfRange, "length %d out of range %d to %d", numberLen, minLength, maxNumberLength)
 	}
 
-	return sum%10 == 0
+	if !Luhn(number) {
+		return ErrChecksumFailed
+	}
+	return nil
 }
@@ -1,7 +1,13 @@
 package creditcard
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -13,7 +19,7 @@ func TestCards(t *testing.T) {
 		Type: "Something", Number: "5019717010103742", ExpiryMonth: 11, ExpiryYear: 2019, CVV: "1234",
 	}
 	val := card.Validate()
-	assert.Contains(val.Errors, "given card type doesn't match determined card type")
+	assert.Contains(val.Errors, "given card type 'Something' doesn't match determined card type 'Dankort'")
 
 	card = Card{
 		Type: "Something", Number: "5019717010103742", ExpiryMonth: 111, ExpiryYear: 2019, CVV: "1234",
@@ -130,7 +136,7 @@ func TestCards(t *testing.T) {
 	assert.Equal(val.Card.Type, "Mastercard")
 
 	card = Card{
-		Number: "351821246310005", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "1234",
+		Number: "3530111333300000", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "1234",
 	}
 	val = card.Validate()
 	assert.Equal(val.Card.Type, "JCB")
@@ -141,6 +147,125 @@ func TestCards(t *testing.T) {
 	val = card.Validate()
 	assert.Equal(val.Card.Type, "Aura")
 
+	for _, number := range []string{"2200123456789019", "2204123456789015"} {
+		card = Card{
+			Number: number, ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+		}
+		val = card.Validate()
+		assert.Equal(val.Card.Type, "Mir")
+		assert.NotEqual(val.Card.Type, "Mastercard")
+		assert.True(val.ValidCardNumber)
+	}
+
+	for _, number := range []string{"2221000000000009", "2223003122003222", "2720990000000007"} {
+		card = Card{
+			Number: number, ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+		}
+		val = card.Validate()
+		assert.Equal(val.Card.Type, "Mastercard")
+		assert.True(val.ValidCardNumber)
+	}
+
+	for _, number := range []string{"2200123456789019", "2220000000000000", "2721000000000000"} {
+		card = Card{Number: number}
+		cardType, _ := card.DetectType()
+		assert.NotEqual(Mastercard, cardType, number)
+	}
+
+	for _, number := range []string{"6081123456789014", "6521500000000006", "8172900000000006"} {
+		card = Card{
+			Number: number, ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+		}
+		val = card.Validate()
+		assert.Equal(val.Card.Type, "RuPay")
+		assert.True(val.ValidCardNumber)
+	}
+
+	for _, number := range []string{"6221260000000000", "6229250000000003", "6240000000000007", "6011000000000000001"} {
+		card = Card{
+			Number: number, ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+		}
+		val = card.Validate()
+		assert.Equal(val.Card.Type, "Discover", number)
+		assert.True(val.ValidCardNumber, number)
+	}
+
+	for _, number := range []string{"6221250000000001", "6229260000000002"} {
+		card = Card{Number: number}
+		cardType, _ := card.DetectType()
+		assert.Equal(ChinaUnionPay, cardType, number)
+	}
+
+	card = Card{
+		Number: "6011111111111117", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val = card.Validate()
+	assert.Equal(val.Card.Type, "Discover")
+
+	card = Card{
+		Number: "6540111111111111", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val = card.Validate()
+	assert.Equal(val.Card.Type, "Discover")
+
+	card = Card{
+		Number: "123456789012347", ExpiryMonth: 11, ExpiryYear: 2020,
+	}
+	val = card.Validate()
+	assert.Equal(val.Card.Type, "UATP")
+	assert.True(val.ValidCardNumber)
+	assert.True(val.ValidCVV)
+
+	card = Card{
+		Number: "123456789012347", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val = card.Validate()
+	assert.True(val.ValidCVV)
+
+	for _, number := range []string{"5060990000000008", "6500270000000000006"} {
+		card = Card{
+			Number: number, ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+		}
+		val = card.Validate()
+		assert.Equal(val.Card.Type, "Verve")
+		assert.True(val.ValidCardNumber)
+	}
+
+	for number, cardType := range map[string]string{"8600123456789012": "UzCard", "9860123456789015": "Humo"} {
+		card = Card{
+			Number: number, ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+		}
+		val = card.Validate()
+		assert.Equal(val.Card.Type, cardType)
+		assert.True(val.ValidCardNumber)
+	}
+
+	card = Card{
+		Number: "5066990000000002", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val = card.Validate()
+	assert.Equal(val.Card.Type, "Elo")
+
+	for _, number := range []string{"6500330000000003", "6500350000000001", "6500510000000000", "6550580000000002"} {
+		card = Card{
+			Number: number, ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+		}
+		val = card.Validate()
+		assert.Equal(val.Card.Type, "Elo", number)
+		assert.True(val.ValidCardNumber, number)
+	}
+
+	card = Card{Number: "6500340000000002"}
+	cardType, _ := card.DetectType()
+	assert.NotEqual(Elo, cardType)
+
+	card = Card{
+		Number: "9792123456789018", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val = card.Validate()
+	assert.Equal(val.Card.Type, "Troy")
+	assert.True(val.ValidCardNumber)
+
 	card = Card{
 		Number: "402621246310005", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "1234",
 	}
@@ -159,3 +284,1146 @@ func TestCards(t *testing.T) {
 	luhn := card.validateLuhn()
 	assert.Equal(luhn, false)
 }
+
+func TestCVVError(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{
+		Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val := card.Validate()
+	assert.True(val.ValidCVV)
+	assert.NotContains(val.Errors, "cvv doesn't match")
+
+	card = Card{
+		Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "12",
+	}
+	val = card.Validate()
+	assert.False(val.ValidCVV)
+	assert.Contains(val.Errors, "cvv doesn't match")
+}
+
+func TestCardNumberError(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{
+		Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val := card.Validate()
+	assert.True(val.ValidCardNumber)
+	assert.NotContains(val.Errors, "card number is not valid")
+
+	card = Card{
+		Number: "4012888888881882", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val = card.Validate()
+	assert.False(val.ValidCardNumber)
+	assert.Contains(val.Errors, "card number is not valid")
+}
+
+func TestBrand(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{Number: "378282246310005"}
+	brand, err := card.Brand()
+	assert.NoError(err)
+	assert.Equal("American Express", brand)
+	assert.Empty(card.Type)
+
+	card = Card{Number: "0000000000"}
+	brand, err = card.Brand()
+	assert.EqualError(err, "unknown creditcard type")
+	assert.Empty(brand)
+}
+
+func TestDetectType(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{Number: "300221246310005"}
+	cardType, err := card.DetectType()
+	assert.NoError(err)
+	assert.Equal(DinersClubCarteBlanche, cardType)
+	assert.Equal("Diners Club Carte Blanche", cardType.String())
+
+	card = Card{Number: "0000000000"}
+	cardType, err = card.DetectType()
+	assert.Error(err)
+	assert.Equal(Unknown, cardType)
+	assert.Equal("Unknown Card", cardType.String())
+}
+
+func TestCardTypeRuleIndex(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prefixes of up to four digits, and the prefixes around the edges of each range
+	prefixes := make([]string, 0)
+	for _, digits := range []int{1, 2, 4} {
+		for i, count := 0, int(math.Pow10(digits)); i < count; i++ {
+			prefixes = append(prefixes, fmt.Sprintf("%0*d", digits, i))
+		}
+	}
+	for _, rule := range cardTypeRules.all {
+		for _, prefix := range []string{rule.from, rule.to} {
+			value, _ := strconv.Atoi(prefix)
+			for _, v := range []int{value - 1, value, value + 1} {
+				prefixes = append(prefixes, fmt.Sprintf("%0*d", len(prefix), v))
+			}
+		}
+	}
+
+	// The index finds the same rule as checking all rules, for the lengths that rules are limited to
+	for _, prefix := range prefixes {
+		for _, length := range []int{len(prefix), 12, 14, 15, 16, 17, 19} {
+			number := prefix + strings.Repeat("0", length-len(prefix))
+			for _, prefixOnly := range []bool{false, true} {
+				expected := Unknown
+				for i := range cardTypeRules.all {
+					if cardTypeRules.all[i].matches(number, prefixOnly) {
+						expected = cardTypeRules.all[i].cardType
+						break
+					}
+				}
+				cardType, _ := detectCardType(number, prefixOnly)
+				if !assert.Equal(expected, cardType, number) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func TestDetectTypeAllBrands(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := map[CardType][]string{
+		AmericanExpress:         {"340000000000000", "378282246310005"},
+		Aura:                    {"5078601870000127985"},
+		Bankcard:                {"5610591081018250", "5602210000000000", "5602250000000000"},
+		Cabal:                   {"6042010000000000", "6042190000000000"},
+		ChinaUnionPay:           {"6200000000000005", "6221250000000001", "6229260000000002"},
+		Dankort:                 {"5019717010103742"},
+		DinersClubCarteBlanche:  {"300221246310005", "305000000000000"},
+		DinersClubEnroute:       {"201400000000009", "214900000000000"},
+		DinersClubInternational: {"30569309025904", "36227206271667", "38520000023237", "39000000000000"},
+		Discover:                {"6011111111111117", "6440000000000000", "6500000000000000", "6221260000000000"},
+		Elo:                     {"4011780000000006", "5066990000000002", "6362970000000000", "6550580000000002"},
+		Hipercard:               {"6062820000000000", "3841000000000000", "6375680000000000", "6376120000000000"},
+		Humo:                    {"9860123456789015", "9860000000000000"},
+		InstaPayment:            {"6371212463100050", "6380000000000000", "6399000000000000"},
+		InterPayment:            {"6360000000000000", "6361000000000000000"},
+		JCB:                     {"3530111333300000", "3566002020360505", "3528000000000007", "3589000000000003"},
+		Maestro:                 {"6759649826438453", "5018000000000000", "0604123456789012", "6390000000000000"},
+		Mastercard:              {"5555555555554444", "5105105105105100", "2223003122003222"},
+		Mir:                     {"2200123456789019", "2204123456789015"},
+		RuPay:                   {"6081123456789014", "6521500000000006", "8172900000000006"},
+		Troy:                    {"9792123456789018"},
+		UATP:                    {"100000000000000"},
+		UzCard:                  {"8600123456789012", "8600000000000007"},
+		Verve:                   {"5060990000000000", "6500020000000000"},
+		Visa:                    {"4012888888881881", "4222222222222", "4000000000000000000"},
+		VisaElectron:            {"4026000000000002", "4175000000000000", "4917300800000000"},
+	}
+
+	for cardType, numbers := range tests {
+		for _, number := range numbers {
+			card := Card{Number: number}
+			detected, err := card.DetectType()
+			assert.NoError(err, number)
+			assert.Equal(cardType, detected, number)
+		}
+	}
+
+	for _, number := range []string{"3527000000000008", "3590000000000000", "3500000000000009", "352800000000009"} {
+		card := Card{Number: number}
+		detected, _ := card.DetectType()
+		assert.NotEqual(JCB, detected, number)
+	}
+
+	card := Card{Number: "3528000000000000007"}
+	val := card.Validate()
+	assert.Equal(JCB, val.DetectedType)
+	assert.True(val.ValidCardNumber)
+
+	// Every card type has to be covered, so new card types can't shadow existing ones unnoticed
+	for i := range cardTypeNames {
+		if cardType := CardType(i); cardType != Unknown {
+			assert.Contains(tests, cardType, cardType.String())
+		}
+	}
+}
+
+func TestSupportedTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	cardTypes := SupportedTypes()
+	names := SupportedTypeNames()
+	assert.Len(cardTypes, len(cardTypeNames)-1)
+	assert.Len(names, len(cardTypes))
+	assert.NotContains(cardTypes, Unknown)
+	assert.Equal(AmericanExpress, cardTypes[0])
+	assert.Equal(VisaElectron, cardTypes[len(cardTypes)-1])
+
+	for i, name := range names {
+		assert.NotEmpty(name)
+		assert.Equal(cardTypes[i].String(), name)
+	}
+	assert.Contains(names, "Visa")
+	assert.NotContains(names, "Unknown Card")
+}
+
+func TestSameAs(t *testing.T) {
+	assert := assert.New(t)
+
+	card := &Card{Number: "4012 8888 8888 1881", ExpiryMonth: 11, ExpiryYear: 2030, CVV: "123"}
+	other := &Card{Number: "4012-8888-8888-1881", ExpiryMonth: 11, ExpiryYear: 2030}
+	assert.True(card.SameAs(other))
+	assert.True(other.SameAs(card))
+	assert.True(card.SameAs(card))
+
+	other = &Card{Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 30, CVV: "456"}
+	assert.True(card.SameAs(other))
+
+	for _, other := range []*Card{
+		{Number: "4111 1111 1111 1111", ExpiryMonth: 11, ExpiryYear: 2030},
+		{Number: "4012 8888 8888 1881", ExpiryMonth: 12, ExpiryYear: 2030},
+		{Number: "4012 8888 8888 1881", ExpiryMonth: 11, ExpiryYear: 2031},
+		nil,
+	} {
+		assert.False(card.SameAs(other), "%+v", other)
+	}
+}
+
+func TestProcessingNetwork(t *testing.T) {
+	assert := assert.New(t)
+
+	for number, network := range map[string]CardType{
+		"36227206271667":   Discover,
+		"30569309025904":   Discover,
+		"300221246310005":  Discover,
+		"6011111111111117": Discover,
+		"6759649826438453": Mastercard,
+		"4917300800000000": Visa,
+		"4012888888881881": Visa,
+		"378282246310005":  AmericanExpress,
+		"0000000000000000": Unknown,
+	} {
+		card := Card{Number: number}
+		assert.Equal(network, card.ProcessingNetwork(), number)
+	}
+
+	// The issuing brand is still detected as Diners Club
+	card := Card{Number: "36227206271667"}
+	cardType, _ := card.DetectType()
+	assert.Equal(DinersClubInternational, cardType)
+}
+
+func TestSetNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	card, err := Parse("4012 8888 8888 1881")
+	assert.NoError(err)
+	assert.Equal("Visa", card.Type)
+	card.Apply(WithExpiry(11, 2020), WithCVV("1234"),
+		WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) }))
+
+	card.SetNumber(" 3782-822463-10005 ")
+	assert.Equal("378282246310005", card.Number)
+	assert.Empty(card.Type)
+
+	val := card.Validate()
+	assert.Equal(AmericanExpress, val.DetectedType)
+	assert.Equal("American Express", val.Card.Type)
+	assert.False(val.HasError(ErrTypeMismatch))
+	assert.True(val.Valid())
+
+	brand, err := card.Brand()
+	assert.NoError(err)
+	assert.Equal("American Express", brand)
+}
+
+func TestExpectedLengths(t *testing.T) {
+	assert := assert.New(t)
+
+	for number, lengths := range map[string][]int{
+		"378282246310005":  {15},
+		"3782":             {15},
+		"4012888888881881": {13, 16, 19},
+		"4":                {13, 16, 19},
+		"6759649826438453": {12, 13, 14, 15, 16, 17, 18, 19},
+		"3528":             {16, 17, 18, 19},
+		"352800":           {16, 17, 18, 19},
+		"8600":             {16},
+		"637100":           {16},
+		"3000":             {14, 15},
+		"36":               {14},
+		"4011780000000006": {16},
+		"6521500000000006": {16},
+		"4011":             {16},
+		"6521":             {16},
+		"65":               {16, 17, 18, 19},
+	} {
+		card := Card{Number: number}
+		assert.Equal(lengths, card.ExpectedLengths(), number)
+	}
+
+	card := Card{Number: "0000"}
+	assert.Nil(card.ExpectedLengths())
+
+	// The lengths of the card type can't be changed through the result
+	card = Card{Number: "4"}
+	card.ExpectedLengths()[0] = 1
+	assert.Equal([]int{13, 16, 19}, card.ExpectedLengths())
+}
+
+func TestDetectBrandFromBIN(t *testing.T) {
+	assert := assert.New(t)
+
+	for bin, expected := range map[string]CardType{
+		"401288":   Visa,
+		"4":        Visa,
+		"40128888": Visa,
+		"378282":   AmericanExpress,
+		"352800":   JCB,
+		"637100":   InstaPayment,
+		"860012":   UzCard,
+		"3056 93":  DinersClubCarteBlanche,
+		"362272":   DinersClubInternational,
+	} {
+		cardType, err := DetectBrandFromBIN(bin)
+		assert.NoError(err, bin)
+		assert.Equal(expected, cardType, bin)
+	}
+
+	_, err := DetectBrandFromBIN("000000")
+	assert.True(errors.Is(err, ErrUnknownType))
+	_, err = DetectBrandFromBIN("")
+	assert.True(errors.Is(err, ErrEmptyNumber))
+	_, err = DetectBrandFromBIN("40a288")
+	assert.True(errors.Is(err, ErrNonDigit))
+
+	// The full number is still required to tell JCB numbers apart by length
+	card := Card{Number: "352800"}
+	cardType, _ := card.DetectType()
+	assert.Equal(Unknown, cardType)
+}
+
+func TestVerifyType(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{Type: "Mastercard", Number: "4012888888881881"}
+	expected, actual, ok := card.VerifyType()
+	assert.Equal("Mastercard", expected)
+	assert.Equal("Visa", actual)
+	assert.False(ok)
+
+	val := card.Validate()
+	assert.True(val.HasError(ErrTypeMismatch))
+	assert.Contains(val.Errors, "given card type 'Mastercard' doesn't match determined card type 'Visa'")
+
+	card = Card{Type: " visa ", Number: "4012888888881881"}
+	expected, actual, ok = card.VerifyType()
+	assert.Equal("visa", expected)
+	assert.Equal("Visa", actual)
+	assert.True(ok)
+
+	card = Card{Number: "4012888888881881"}
+	_, actual, ok = card.VerifyType()
+	assert.Equal("Visa", actual)
+	assert.True(ok)
+
+	card = Card{Type: "Visa", Number: "0000000000"}
+	_, actual, ok = card.VerifyType()
+	assert.Equal("Unknown Card", actual)
+	assert.False(ok)
+}
+
+func TestPossibleTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{Number: "4011 7800 0000 0006"}
+	assert.Equal([]CardType{Elo, Visa}, card.PossibleTypes())
+
+	cardType, err := card.DetectType()
+	assert.NoError(err)
+	assert.Equal(Elo, cardType)
+
+	card = Card{Number: "4012888888881881"}
+	assert.Equal([]CardType{Visa}, card.PossibleTypes())
+
+	card = Card{Number: "0000000000"}
+	assert.Empty(card.PossibleTypes())
+}
+
+func TestExpiryMonth(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{
+		Number: "4012888888881881", ExpiryMonth: 12, ExpiryYear: 2020, CVV: "123",
+	}
+
+	for _, now := range []time.Time{
+		time.Date(2020, time.December, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, time.December, 15, 12, 0, 0, 0, time.UTC),
+		time.Date(2020, time.December, 31, 23, 59, 59, 0, time.UTC),
+	} {
+		now := now
+		card.Apply(WithClock(func() time.Time { return now }))
+		val := card.Validate()
+		assert.False(val.IsExpired, now.String())
+		assert.NotContains(val.Errors, "creditcard is expired")
+	}
+
+	card.Apply(WithClock(func() time.Time { return time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC) }))
+	val := card.Validate()
+	assert.True(val.IsExpired)
+	assert.Contains(val.Errors, "creditcard is expired")
+}
+
+func TestNumberSeparators(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, number := range []string{"4012 8888 8888 1881", "4012-8888-8888-1881", " 4012-8888 8888-1881 "} {
+		card := Card{
+			Number: number, ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+		}
+		val := card.Validate()
+		assert.True(val.ValidCardNumber, number)
+		assert.Equal("Visa", val.Card.Type)
+		assert.NotContains(val.Errors, "card number contains non-digit characters")
+	}
+
+	card := Card{
+		Number: "4012.8888.8888.1881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val := card.Validate()
+	assert.False(val.ValidCardNumber)
+	assert.Contains(val.Errors, "card number contains non-digit characters")
+}
+
+func TestNonDigitNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{
+		Number: "4012abcd88881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	assert.False(card.validateLuhn())
+
+	val := card.Validate()
+	assert.False(val.ValidCardNumber)
+	assert.Contains(val.Errors, "card number contains non-digit characters")
+}
+
+func TestNumericCVV(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{
+		Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "12a",
+	}
+	val := card.Validate()
+	assert.False(val.ValidCVV)
+	assert.Contains(val.Errors, "cvv must be numeric")
+
+	card = Card{
+		Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val = card.Validate()
+	assert.True(val.ValidCVV)
+	assert.NotContains(val.Errors, "cvv must be numeric")
+
+	card = Card{
+		Number: "378282246310005", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val = card.Validate()
+	assert.False(val.ValidCVV)
+	assert.Contains(val.Errors, "cvv doesn't match")
+}
+
+func TestHasError(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{
+		Type: "Visa", Number: "5019717010103742", ExpiryMonth: 13, ExpiryYear: 1899, CVV: "12a",
+	}
+	val := card.Validate()
+	assert.True(val.HasError(ErrInvalidMonth))
+	assert.True(val.HasError(ErrInvalidYear))
+	assert.True(val.HasError(ErrExpired))
+	assert.True(val.HasError(ErrTypeMismatch))
+	assert.True(val.HasError(ErrInvalidNumber))
+	assert.True(val.HasError(ErrCVVNotNumeric))
+	assert.False(val.HasError(ErrUnknownType))
+	assert.False(val.HasError(ErrCVVMismatch))
+	assert.Contains(val.Errors, "month '13' is not a valid month")
+	assert.Contains(val.Errors, "year '1899' is not a valid year")
+
+	card = Card{
+		Number: "0000000000", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "1234",
+	}
+	val = card.Validate()
+	assert.True(val.HasError(ErrUnknownType))
+	assert.True(val.HasError(ErrCVVMismatch))
+	assert.False(val.HasError(ErrTypeMismatch))
+}
+
+func TestUnknownTypeWithGivenType(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) })
+
+	// A number of all zeros and a number with a prefix of no card type are reported the same way
+	for _, number := range []string{"0000000000000000", "9999999999999995"} {
+		card := NewCard(number, WithType(Visa), WithExpiry(11, 2020), WithCVV("123"), clock)
+		val := card.Validate()
+		assert.Equal(Unknown, val.DetectedType, number)
+		assert.True(val.HasError(ErrUnknownType), number)
+		assert.False(val.HasError(ErrTypeMismatch), number)
+		assert.Equal([]string{
+			"unknown creditcard type, the card number doesn't match given card type 'Visa'",
+			"card number is not valid",
+		}, val.Errors, number)
+
+		val = card.ValidateStrict()
+		assert.Equal([]string{"unknown creditcard type, the card number doesn't match given card type 'Visa'"}, val.Errors, number)
+		assert.True(errors.Is(card.Check(), ErrUnknownType), number)
+
+		// Without a given card type, the unknown card type is reported once
+		card.Type = ""
+		val = card.Validate()
+		assert.Equal([]string{"unknown creditcard type", "card number is not valid"}, val.Errors, number)
+	}
+}
+
+func TestEmptyNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) })
+
+	for _, number := range []string{"", "  ", " - "} {
+		card := NewCard(number, WithExpiry(11, 2020), WithCVV("123"), clock)
+		val := card.Validate()
+		assert.Equal([]string{"card number is empty"}, val.Errors, number)
+		assert.True(val.HasError(ErrEmptyNumber))
+		assert.False(val.ValidCardNumber)
+		assert.False(val.ValidCVV)
+		assert.Equal(Unknown, val.DetectedType)
+		assert.True(val.ValidExpiryMonth)
+		assert.True(val.ValidExpiryYear)
+		assert.False(val.IsExpired)
+	}
+}
+
+func TestZeroValueCard(t *testing.T) {
+	assert := assert.New(t)
+
+	val := (&Card{}).Validate()
+	assert.NotNil(val)
+	assert.Equal([]string{"expiry date is missing", "card number is empty"}, val.Errors)
+	assert.True(val.HasError(ErrMissingExpiry))
+	assert.True(val.HasError(ErrEmptyNumber))
+	assert.False(val.ValidCardNumber)
+	assert.False(val.ValidExpiryMonth)
+	assert.False(val.ValidExpiryYear)
+	assert.False(val.ValidCVV)
+	assert.False(val.IsExpired)
+	assert.False(val.TypeProvided)
+	assert.Equal(Unknown, val.DetectedType)
+	assert.False(val.Valid())
+	assert.NotEmpty(val.String())
+
+	// A missing expiry date is only reported when both the month and year are missing
+	val = (&Card{Number: "4012888888881881", ExpiryMonth: 13}).Validate()
+	assert.False(val.HasError(ErrMissingExpiry))
+	assert.True(val.HasError(ErrIncompleteExpiry))
+	assert.True(val.HasError(ErrInvalidMonth))
+	assert.False(val.HasError(ErrInvalidYear))
+}
+
+func TestIncompleteExpiry(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2024, time.October, 15, 0, 0, 0, 0, time.UTC) })
+
+	card := NewCard("4012888888881881", WithExpiry(11, 0), WithCVV("123"), clock)
+	val := card.Validate()
+	assert.Equal([]string{"incomplete expiry date"}, val.Errors)
+	assert.True(val.HasError(ErrIncompleteExpiry))
+	assert.True(val.ValidExpiryMonth)
+	assert.False(val.ValidExpiryYear)
+	assert.False(val.IsExpired)
+	assert.False(val.Valid())
+
+	card = NewCard("4012888888881881", WithExpiry(0, 2025), WithCVV("123"), clock)
+	val = card.Validate()
+	assert.Equal([]string{"incomplete expiry date"}, val.Errors)
+	assert.False(val.ValidExpiryMonth)
+	assert.True(val.ValidExpiryYear)
+	assert.False(val.IsExpired)
+
+	// The part that is set is still validated
+	card = NewCard("4012888888881881", WithExpiry(0, 2050), WithCVV("123"), clock)
+	val = card.Validate()
+	assert.Equal([]string{"incomplete expiry date", "year '2050' is not a valid year"}, val.Errors)
+}
+
+func TestWarnings(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2020, time.November, 15, 0, 0, 0, 0, time.UTC) })
+
+	// The card expires at the end of the month, which is a warning and not an error
+	card := NewCard("4532015112830366", WithExpiry(11, 2020), WithCVV("123"), clock)
+	val := card.Validate()
+	assert.True(val.Valid())
+	assert.Empty(val.Errors)
+	assert.Equal([]string{"card expires within 30 days"}, val.Warnings)
+	assert.True(val.HasWarning(ErrExpiringSoon))
+	assert.False(val.HasError(ErrExpiringSoon))
+
+	card.Apply(WithExpiry(12, 2021))
+	val = card.Validate()
+	assert.Empty(val.Warnings)
+	assert.False(val.HasWarning(ErrExpiringSoon))
+
+	// Expired cards are reported as errors only
+	card.Apply(WithExpiry(10, 2020))
+	val = card.Validate()
+	assert.True(val.HasError(ErrExpired))
+	assert.Empty(val.Warnings)
+
+	card = NewCard("5105105105105100", WithExpiry(12, 2021), WithCVV("123"), clock)
+	val = card.Validate()
+	assert.True(val.Valid())
+	assert.True(val.HasWarning(ErrTestCard))
+	assert.False(val.HasWarning(ErrSuspiciousPattern))
+
+	card = NewCard("4111111111111111", WithExpiry(12, 2021), WithCVV("123"), clock)
+	val = card.Validate()
+	assert.True(val.Valid())
+	assert.Equal([]string{"card number has a suspicious pattern", "card number is a test number"}, val.Warnings)
+}
+
+func TestCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) })
+
+	card := NewCard("4012888888881881", WithExpiry(11, 2020), WithCVV("123"), clock)
+	assert.NoError(card.Check())
+
+	card = NewCard("4012888888881882", WithExpiry(9, 2020), WithCVV("12a"), clock)
+	err := card.Check()
+	assert.Error(err)
+	for _, target := range []error{ErrExpired, ErrCVVNotNumeric, ErrChecksumFailed, ErrInvalidNumber} {
+		assert.True(errors.Is(err, target), target.Error())
+	}
+	assert.False(errors.Is(err, ErrInvalidMonth))
+	assert.Equal("creditcard is expired\ncvv must be numeric\nchecksum failed\ncard number is not valid", err.Error())
+}
+
+func TestFailedChecks(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) })
+
+	card := NewCard("4012888888881881", WithExpiry(11, 2020), WithCVV("123"), clock)
+	assert.Empty(card.Validate().FailedChecks())
+
+	for expected, card := range map[string]*Card{
+		CheckCardNumber:  NewCard("4012888888881882", WithExpiry(11, 2020), WithCVV("123"), clock),
+		CheckExpiryMonth: NewCard("4012888888881881", WithExpiry(13, 2020), WithCVV("123"), clock),
+		CheckCVV:         NewCard("4012888888881881", WithExpiry(11, 2020), WithCVV("12"), clock),
+		CheckExpired:     NewCard("4012888888881881", WithExpiry(9, 2020), WithCVV("123"), clock),
+	} {
+		checks := card.Validate().FailedChecks()
+		if expected == CheckExpiryMonth {
+			// An invalid expiry month also means the card is treated as expired
+			assert.Equal([]string{CheckExpiryMonth, CheckExpired}, checks)
+			continue
+		}
+		assert.Equal([]string{expected}, checks)
+	}
+
+	card = NewCard("4012888888881881", WithExpiry(11, 2019), WithCVV("123"), clock)
+	assert.Equal([]string{CheckExpiryYear, CheckExpired}, card.Validate().FailedChecks())
+}
+
+func TestValid(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) })
+
+	card := Card{
+		Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	card.Apply(clock)
+	val := card.Validate()
+	assert.True(val.Valid())
+	assert.Empty(val.Errors)
+
+	for _, card := range []Card{
+		{Number: "4012888888881882", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123"},
+		{Number: "4012888888881881", ExpiryMonth: 13, ExpiryYear: 2020, CVV: "123"},
+		{Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 1899, CVV: "123"},
+		{Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "12"},
+		{Number: "4012888888881881", ExpiryMonth: 9, ExpiryYear: 2020, CVV: "123"},
+		{Type: "Mastercard", Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123"},
+	} {
+		card := card
+		card.Apply(clock)
+		val := card.Validate()
+		assert.False(val.Valid(), "%+v", card)
+	}
+
+	val = &Validation{
+		ValidCardNumber: true, ValidExpiryMonth: true, ValidExpiryYear: true, ValidCVV: true,
+		Errors: []string{"something went wrong"},
+	}
+	assert.False(val.Valid())
+}
+
+func TestNumberLength(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{
+		Number: "3782822463100003", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "1234",
+	}
+	val := card.Validate()
+	assert.False(val.ValidCardNumber)
+	assert.True(val.HasError(ErrInvalidLength))
+	assert.Contains(val.Errors, "length 16 invalid for American Express")
+
+	card = Card{
+		Number: "378282246310005", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "1234",
+	}
+	val = card.Validate()
+	assert.True(val.ValidCardNumber)
+	assert.False(val.HasError(ErrInvalidLength))
+}
+
+func TestCVVLength(t *testing.T) {
+	assert := assert.New(t)
+
+	for cvv, valid := range map[string]bool{"1234": true, "123": false, "": false} {
+		card := Card{
+			Number: "378282246310005", ExpiryMonth: 11, ExpiryYear: 2020, CVV: cvv,
+		}
+		assert.Equal(valid, card.Validate().ValidCVV, cvv)
+	}
+
+	for cvv, valid := range map[string]bool{"1234": false, "123": true, "": false} {
+		card := Card{
+			Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: cvv,
+		}
+		assert.Equal(valid, card.Validate().ValidCVV, cvv)
+	}
+
+	card := Card{
+		Type: "American Express", Number: "0000000000", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	assert.True(card.Validate().ValidCVV)
+}
+
+func TestCVVUsesDetectedType(t *testing.T) {
+	assert := assert.New(t)
+
+	// The CVV is validated against the detected card type, not against the given type
+	card := Card{
+		Type: "amex", Number: "378282246310005", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "1234",
+	}
+	val := card.Validate()
+	assert.True(val.ValidCVV)
+	assert.False(val.HasError(ErrCVVMismatch))
+
+	card.CVV = "123"
+	val = card.Validate()
+	assert.False(val.ValidCVV)
+	assert.True(val.HasError(ErrCVVMismatch))
+
+	card = Card{
+		Type: "Visa", Number: "378282246310005", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "1234",
+	}
+	assert.True(card.Validate().ValidCVV)
+}
+
+func TestDetectedType(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{
+		Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val := card.Validate()
+	assert.Equal(Visa, val.DetectedType)
+
+	card = Card{
+		Type: "Mastercard", Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val = card.Validate()
+	assert.Equal(Visa, val.DetectedType)
+	assert.Equal("Mastercard", val.Card.Type)
+
+	card = Card{
+		Number: "0000000000", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val = card.Validate()
+	assert.Equal(Unknown, val.DetectedType)
+}
+
+func TestValidateDoesNotMutate(t *testing.T) {
+	assert := assert.New(t)
+
+	card := &Card{
+		Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	original := *card
+
+	first := card.Validate()
+	assert.Equal(original, *card)
+	assert.Equal("Visa", first.Card.Type)
+	assert.False(card == first.Card)
+
+	second := card.Validate()
+	assert.Equal(original, *card)
+	assert.Equal(first, second)
+}
+
+func TestNonASCIIDigitNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{
+		Number: "４０１２８８８８８８８８１８８１", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val := card.Validate()
+	assert.False(val.ValidCardNumber)
+	assert.True(val.HasError(ErrNonASCIIDigit))
+	assert.Contains(val.Errors, "card number contains non-ASCII digits")
+	assert.NotContains(val.Errors, "card number contains non-digit characters")
+
+	card = Card{
+		Number: "4012８８８８88881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val = card.Validate()
+	assert.True(val.HasError(ErrNonASCIIDigit))
+	assert.False(card.validateLuhn())
+}
+
+func FuzzDetermineCardType(f *testing.F) {
+	for _, number := range []string{"", "0", "4", "06", "34", "060", "0604", "4012888888881881", "378282246310005", "4012 8888-8888 1881"} {
+		f.Add(number)
+	}
+
+	f.Fuzz(func(t *testing.T, number string) {
+		card := Card{Number: number}
+		cardType, err := card.determineCardType()
+		if err != nil && cardType != Unknown {
+			t.Errorf("expected Unknown card type on error, got %s", cardType)
+		}
+		card.Validate()
+	})
+}
+
+func FuzzValidateLuhn(f *testing.F) {
+	for _, number := range []string{"", "0", "4", "06", "4012888888881881", "4012abcd88881881", "４０１２８８８８８８８８１８８１"} {
+		f.Add(number)
+	}
+
+	f.Fuzz(func(t *testing.T, number string) {
+		card := Card{Number: number}
+		if card.validateLuhn() && !Luhn(number) {
+			t.Errorf("number %q passes validateLuhn but not Luhn", number)
+		}
+	})
+}
+
+func TestShortNumbers(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, number := range []string{"", "1", "06", "060"} {
+		card := Card{
+			Number: number, ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+		}
+		assert.NotPanics(func() { card.Validate() }, number)
+		val := card.Validate()
+		assert.False(val.ValidCardNumber)
+		assert.Equal(Unknown, val.DetectedType)
+	}
+
+	card := Card{Number: "0604123456789012"}
+	cardType, err := card.DetectType()
+	assert.NoError(err)
+	assert.Equal(Maestro, cardType)
+}
+
+func TestValidateStrict(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) })
+
+	card := NewCard("0000000000", WithExpiry(11, 2020), WithCVV("123"), clock)
+	val := card.ValidateStrict()
+	assert.False(val.Valid())
+	assert.False(val.ValidCardNumber)
+	assert.False(val.ValidExpiryMonth)
+	assert.False(val.ValidExpiryYear)
+	assert.False(val.ValidCVV)
+	assert.Equal(Unknown, val.DetectedType)
+	assert.Equal([]string{"unknown creditcard type"}, val.Errors)
+	assert.True(val.HasError(ErrUnknownType))
+
+	// The lenient default still reports the other checks
+	val = card.Validate()
+	assert.False(val.Valid())
+	assert.True(val.ValidExpiryMonth)
+	assert.True(val.ValidCVV)
+
+	card = NewCard("4012888888881881", WithExpiry(11, 2020), WithCVV("123"), clock)
+	val = card.ValidateStrict()
+	assert.True(val.Valid())
+	assert.Equal(Visa, val.DetectedType)
+	assert.Equal("Visa", val.Card.Type)
+}
+
+// benchmarkClock is the clock used in benchmarks, so the validated cards are not expired
+var benchmarkClock = WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) })
+
+func BenchmarkValidate(b *testing.B) {
+	card := Card{
+		Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	card.Apply(benchmarkClock)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		card.Validate()
+	}
+}
+
+func BenchmarkDetermineType(b *testing.B) {
+	card := Card{Number: "4012888888881881"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		card.DetectType()
+	}
+}
+
+func BenchmarkValidateLuhn(b *testing.B) {
+	card := Card{Number: "4012888888881881"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		card.validateLuhn()
+	}
+}
+
+func BenchmarkValidateParallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		card := Card{
+			Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+		}
+		card.Apply(benchmarkClock)
+		for pb.Next() {
+			card.Validate()
+		}
+	})
+}
+
+func TestZeroAllocations(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{Number: "4012888888881881"}
+	assert.Zero(testing.AllocsPerRun(100, func() { card.DetectType() }))
+	assert.Zero(testing.AllocsPerRun(100, func() { card.validateLuhn() }))
+}
+
+func TestRequiresCVV(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, number := range []string{"4012888888881881", "378282246310005", "5555555555554444", "0000000000"} {
+		card := Card{
+			Number: number, ExpiryMonth: 11, ExpiryYear: 2020,
+		}
+		assert.True(card.RequiresCVV(), number)
+		val := card.Validate()
+		assert.False(val.ValidCVV, number)
+		assert.True(val.HasError(ErrCVVMismatch), number)
+	}
+
+	for _, number := range []string{"123456789012347", "6759649826438453"} {
+		card := Card{
+			Number: number, ExpiryMonth: 11, ExpiryYear: 2020,
+		}
+		assert.False(card.RequiresCVV(), number)
+		val := card.Validate()
+		assert.True(val.ValidCVV, number)
+		assert.False(val.HasError(ErrCVVMismatch), number)
+	}
+}
+
+func TestNineteenDigitNumbers(t *testing.T) {
+	assert := assert.New(t)
+
+	for number, cardType := range map[string]CardType{
+		"4012888888881881003": Visa,
+		"4012888888881881":    Visa,
+		"6011111111111117000": Discover,
+		"6011111111111117":    Discover,
+	} {
+		card := Card{
+			Number: number, ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+		}
+		val := card.Validate()
+		assert.Equal(cardType, val.DetectedType, number)
+		assert.True(val.ValidCardNumber, number)
+		assert.False(val.HasError(ErrInvalidLength), number)
+	}
+
+	card := Card{
+		Number: "40128888888818810002", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	assert.False(card.Validate().ValidCardNumber)
+
+	card = Card{
+		Number: "40128888888818813", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val := card.Validate()
+	assert.Equal(Visa, val.DetectedType)
+	assert.False(val.ValidCardNumber)
+	assert.Contains(val.Errors, "length 17 invalid for Visa")
+}
+
+func TestValidationString(t *testing.T) {
+	assert := assert.New(t)
+
+	card := NewCard("4012888888881881", WithExpiry(1, 2020), WithCVV("12"),
+		WithClock(func() time.Time { return time.Date(2019, time.October, 15, 0, 0, 0, 0, time.UTC) }))
+	s := card.Validate().String()
+	assert.Equal(`Card type:    Visa
+Number:       ************1881
+Expiry:       01/20
+Valid number: pass
+Valid month:  pass
+Valid year:   pass
+Valid CVV:    fail
+Expired:      false
+Errors:       cvv doesn't match`, s)
+	assert.NotContains(s, "4012888888881881")
+
+	s = fmt.Sprintf("%v", card.Validate())
+	assert.NotContains(s, "4012888888881881")
+	assert.Contains(s, "************1881")
+
+	assert.NotPanics(func() { _ = (&Validation{}).String() })
+}
+
+func TestWhitespace(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{
+		Type: " Visa ", Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: " 123 ",
+	}
+	val := card.Validate()
+	assert.True(val.ValidCVV)
+	assert.True(val.ValidCardNumber)
+	assert.False(val.HasError(ErrTypeMismatch))
+	assert.Equal("Visa", val.Card.Type)
+	assert.Equal("123", val.Card.CVV)
+	assert.Equal(" 123 ", card.CVV)
+
+	card = Card{
+		Type: "\tAmerican Express\n", Number: "378282246310005", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "1234 ",
+	}
+	val = card.Validate()
+	assert.True(val.ValidCVV)
+	assert.True(val.ValidCardNumber)
+}
+
+func TestTypeCase(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, cardType := range []string{"visa", "VISA", "ViSa", " visa "} {
+		card := Card{
+			Type: cardType, Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+		}
+		val := card.Validate()
+		assert.False(val.HasError(ErrTypeMismatch), cardType)
+		assert.True(val.ValidCardNumber, cardType)
+	}
+
+	card := Card{
+		Type: "american express", Number: "378282246310005", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "1234",
+	}
+	assert.True(card.Validate().ValidCardNumber)
+
+	card = Card{
+		Type: "visa electron", Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	assert.True(card.Validate().HasError(ErrTypeMismatch))
+}
+
+func TestTypeConstantNames(t *testing.T) {
+	assert := assert.New(t)
+
+	for cardType, number := range map[string]string{
+		"AmericanExpress":        "378282246310005",
+		"DinersClubCarteBlanche": "300221246310005",
+		"ChinaUnionPay":          "620221246310005",
+		"visaelectron":           "402621246310005",
+	} {
+		card := Card{Type: cardType, Number: number}
+		assert.False(card.Validate().HasError(ErrTypeMismatch), cardType)
+	}
+
+	card := NewCard("300221246310005", WithType(DinersClubCarteBlanche))
+	assert.False(card.Validate().HasError(ErrTypeMismatch))
+
+	card = &Card{Type: "DinersClub", Number: "300221246310005"}
+	assert.True(card.Validate().HasError(ErrTypeMismatch))
+}
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+
+	card, err := Parse("4012 8888-8888 1881")
+	assert.NoError(err)
+	assert.Equal("4012888888881881", card.Number)
+	assert.Equal("Visa", card.Type)
+
+	card, err = Parse("4012abcd88881881")
+	assert.Nil(card)
+	assert.Equal(ErrNonDigit, err)
+
+	card, err = Parse("4012")
+	assert.Nil(card)
+	assert.True(errors.Is(err, ErrInvalidLength))
+	assert.EqualError(err, "length 4 invalid for Visa")
+
+	card, err = Parse("0000000000")
+	assert.Nil(card)
+	assert.Equal(ErrUnknownType, err)
+}
+
+func TestTypeProvided(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{
+		Type: "Visa", Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val := card.Validate()
+	assert.True(val.TypeProvided)
+	assert.Equal("Visa", val.Card.Type)
+
+	card = Card{
+		Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	val = card.Validate()
+	assert.False(val.TypeProvided)
+	assert.Equal("Visa", val.Card.Type)
+
+	card = Card{
+		Type: "  ", Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	assert.False(card.Validate().TypeProvided)
+
+	card = Card{Type: "Visa", Number: "0000000000"}
+	assert.True(card.ValidateStrict().TypeProvided)
+	card = Card{Number: "0000000000"}
+	assert.False(card.ValidateStrict().TypeProvided)
+}
@@ -0,0 +1,77 @@
+package creditcard
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidMonth is returned when the expiry month is not a valid month
+	ErrInvalidMonth = errors.New("invalid expiry month")
+	// ErrInvalidYear is returned when the expiry year is not a valid year
+	ErrInvalidYear = errors.New("invalid expiry year")
+	// ErrMissingExpiry is returned when neither the expiry month nor the expiry year is set
+	ErrMissingExpiry = errors.New("expiry date is missing")
+	// ErrIncompleteExpiry is returned when only one of the expiry month and the expiry year is set
+	ErrIncompleteExpiry = errors.New("incomplete expiry date")
+	// ErrExpired is returned when the expiry date of the card has been reached
+	ErrExpired = errors.New("creditcard is expired")
+	// ErrUnknownType is returned when the card type can't be determined from the card number
+	ErrUnknownType = errors.New("unknown creditcard type")
+	// ErrTypeMismatch is returned when the given card type doesn't match the card type determined from the card number
+	ErrTypeMismatch = errors.New("given card type doesn't match determined card type")
+	// ErrEmptyNumber is returned when the card number is empty
+	ErrEmptyNumber = errors.New("card number is empty")
+	// ErrTypeNotAccepted is returned when the card type is not one of the card types accepted with WithAcceptedTypes
+	ErrTypeNotAccepted = errors.New("brand not accepted")
+	// ErrNonDigit is returned when the card number contains characters other than digits, spaces, and dashes
+	ErrNonDigit = errors.New("card number contains non-digit characters")
+	// ErrNonASCIIDigit is returned when the card number contains Unicode digits other than the ASCII digits 0 to 9
+	ErrNonASCIIDigit = errors.New("card number contains non-ASCII digits")
+	// ErrInvalidLength is returned when the length of the card number is not valid for the card type
+	ErrInvalidLength = errors.New("invalid card number length")
+	// ErrLengthOutOfRange is returned when the card number is shorter than 13 or longer than 19 digits
+	ErrLengthOutOfRange = errors.New("length out of range")
+	// ErrChecksumFailed is returned when the card number doesn't pass the Luhn algorithm
+	ErrChecksumFailed = errors.New("checksum failed")
+	// ErrInvalidNumber is returned when the card number is not valid, for example because it fails the Luhn algorithm
+	ErrInvalidNumber = errors.New("card number is not valid")
+	// ErrCVVNotNumeric is returned when the CVV contains characters other than digits
+	ErrCVVNotNumeric = errors.New("cvv must be numeric")
+	// ErrCVVMismatch is returned when the length of the CVV doesn't match the length expected for the card type
+	ErrCVVMismatch = errors.New("cvv doesn't match")
+)
+
+// Warnings are advisory, so they are reported in the Warnings of a Validation and don't make a card invalid
+var (
+	// ErrExpiringSoon is reported when the card expires within 30 days
+	ErrExpiringSoon = errors.New("card expires within 30 days")
+	// ErrSuspiciousPattern is reported when the card number looks made up, see IsSuspiciousPattern
+	ErrSuspiciousPattern = errors.New("card number has a suspicious pattern")
+	// ErrTestCard is reported when the card number is a test number published by a payment processor
+	ErrTestCard = errors.New("card number is a test number")
+)
+
+// validationError is an error with a detailed message that matches the more generic error it wraps
+type validationError struct {
+	err error
+	msg string
+}
+
+// Error returns the detailed message of the error
+func (e *validationError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the generic error, so the error can be matched with errors.Is
+func (e *validationError) Unwrap() error {
+	return e.err
+}
+
+// newError creates an error with a formatted message that wraps the generic error
+func newError(err error, format string, args ...interface{}) error {
+	return &validationError{
+		err: err,
+		msg: fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,120 @@
+package creditcard
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultExpiryYears is the number of years after the current year that are accepted as expiry year, unless configured otherwise
+const defaultExpiryYears = 20
+
+// ParseExpiry parses an expiry date in the MM/YY or MM/YYYY format, as it is commonly entered in payment forms.
+// Both '/' and '-' are accepted as separator. Two digit years are expanded to the year closest to the current
+// year, within a window ranging from 79 years in the past up to 20 years in the future
+func ParseExpiry(s string) (month int, year int, err error) {
+	return parseExpiry(s, time.Now())
+}
+
+// SetExpiry parses an expiry date in the MM/YY or MM/YYYY format and sets the expiry month and year of the card.
+// Two digit years are expanded based on the clock of the card
+func (c *Card) SetExpiry(s string) error {
+	month, year, err := parseExpiry(s, c.now())
+	if err != nil {
+		return err
+	}
+
+	c.ExpiryMonth = month
+	c.ExpiryYear = year
+	return nil
+}
+
+// parseExpiry parses an expiry date in the MM/YY or MM/YYYY format, expanding two digit years relative to now
+func parseExpiry(s string, now time.Time) (int, int, error) {
+	// The month and year are separated by exactly one separator, so the year can't contain another separator
+	value := strings.TrimSpace(s)
+	i := strings.IndexAny(value, "/-")
+	if i < 0 {
+		return 0, 0, fmt.Errorf("expiry '%s' is not in the MM/YY or MM/YYYY format", s)
+	}
+	parts := [2]string{value[:i], value[i+1:]}
+	if len(parts[0]) < 1 || len(parts[0]) > 2 || (len(parts[1]) != 2 && len(parts[1]) != 4) ||
+		!isDigits(parts[0]) || !isDigits(parts[1]) {
+		return 0, 0, fmt.Errorf("expiry '%s' is not in the MM/YY or MM/YYYY format", s)
+	}
+
+	month, _ := strconv.Atoi(parts[0])
+	if month < 1 || month > 12 {
+		return 0, 0, newError(ErrInvalidMonth, "month '%d' is not a valid month", month)
+	}
+
+	year, _ := strconv.Atoi(parts[1])
+	if len(parts[1]) == 2 {
+		year = expandYear(year, now)
+	}
+
+	return month, year, nil
+}
+
+// expandYear expands a two digit year to a four digit year. The result is the year closest to now
+// within a window ranging from 79 years in the past up to 20 years in the future
+func expandYear(year int, now time.Time) int {
+	year += now.Year() / 100 * 100
+	switch {
+	case year > now.Year()+20:
+		year -= 100
+	case year <= now.Year()-80:
+		year += 100
+	}
+	return year
+}
+
+// ValidateExpiry validates an expiry month and year without a card number, for example when only the expiry
+// date of a stored card is updated. The checks are the same as those of Validate, relative to the given time
+func ValidateExpiry(month, year int, now time.Time) (validMonth, validYear, expired bool) {
+	card := NewCard("", WithExpiry(month, year), WithClock(func() time.Time { return now }))
+	return card.validExpiryMonth(), card.validExpiryYear(), card.isExpired()
+}
+
+// ExpiryDate returns the last moment of the expiry month in UTC, which is the last moment the card can be used.
+// An error is returned when the expiry month or year isn't valid. Unlike Validate, years outside of the accepted
+// range of expiry years are allowed, so the expiry date of cards that expired long ago can still be determined
+func (c *Card) ExpiryDate() (time.Time, error) {
+	if !c.validExpiryMonth() {
+		return time.Time{}, newError(ErrInvalidMonth, "month '%d' is not a valid month", c.ExpiryMonth)
+	}
+	if c.ExpiryYear < 1 {
+		return time.Time{}, newError(ErrInvalidYear, "year '%d' is not a valid year", c.ExpiryYear)
+	}
+
+	return c.expiryEnd().Add(-time.Nanosecond), nil
+}
+
+// ExpiryString returns the expiry date in the MM/YY format, as it is printed on cards and receipts. The month
+// is padded to two digits and only the last two digits of the year are used
+func (c *Card) ExpiryString() string {
+	return fmt.Sprintf("%02d/%02d", c.ExpiryMonth, c.expiryYear()%100)
+}
+
+// expiryYear returns the expiry year of the card, expanding two digit years (1 to 99) to a four digit year.
+// Like ParseExpiry, the expanded year is the year closest to the current year within a window ranging from
+// 79 years in the past up to 20 years in the future, so with a current year of 2024, 25 is expanded to 2025
+// and 50 is expanded to 1950
+func (c *Card) expiryYear() int {
+	if c.ExpiryYear > 0 && c.ExpiryYear < 100 {
+		return expandYear(c.ExpiryYear, c.now())
+	}
+	return c.ExpiryYear
+}
+
+// IsExpiringSoon reports whether the card expires within the given duration from now. Cards are valid
+// up to and including the last day of the expiry month, extended by the grace period if there is one, so
+// a card in its grace period is expiring soon when the grace period ends within the given duration. Cards
+// that are already expired, or have an invalid expiry date, are not considered to be expiring soon
+func (c *Card) IsExpiringSoon(within time.Duration) bool {
+	if c.isExpired() {
+		return false
+	}
+	return c.expiryEnd().Add(c.opts.expiryGrace).Sub(c.now()) <= within
+}
@@ -0,0 +1,175 @@
+package creditcard
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExpiry(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	for input, expected := range map[string][2]int{
+		"11/25":   {11, 2025},
+		"11/2025": {11, 2025},
+		"1-25":    {1, 2025},
+		"01-2025": {1, 2025},
+		" 11/44 ": {11, 2044},
+		"11/45":   {11, 1945},
+		"11/99":   {11, 1999},
+	} {
+		month, year, err := parseExpiry(input, now)
+		assert.NoError(err, input)
+		assert.Equal(expected[0], month, input)
+		assert.Equal(expected[1], year, input)
+	}
+
+	_, _, err := parseExpiry("13/99", now)
+	assert.EqualError(err, "month '13' is not a valid month")
+
+	for _, input := range []string{"foo", "", "11", "11/2", "11/202", "111/25", "1a/25", "11/25/25",
+		"11/2025/", "/11/25", "11//25", "11/-25", "-11-25-", "11-25-", "/25"} {
+		_, _, err = parseExpiry(input, now)
+		assert.EqualError(err, "expiry '"+input+"' is not in the MM/YY or MM/YYYY format", input)
+	}
+
+	month, year, err := ParseExpiry("11/2025")
+	assert.NoError(err)
+	assert.Equal(11, month)
+	assert.Equal(2025, year)
+}
+
+func TestSetExpiry(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{Number: "4012888888881881"}
+	card.Apply(WithClock(func() time.Time { return time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC) }))
+
+	assert.NoError(card.SetExpiry("11/25"))
+	assert.Equal(11, card.ExpiryMonth)
+	assert.Equal(2025, card.ExpiryYear)
+
+	assert.Error(card.SetExpiry("foo"))
+	assert.Equal(11, card.ExpiryMonth)
+	assert.Equal(2025, card.ExpiryYear)
+}
+
+func TestIsExpiringSoon(t *testing.T) {
+	assert := assert.New(t)
+
+	days := func(n int) time.Duration { return time.Duration(n) * 24 * time.Hour }
+
+	// The card expires at the end of December, 20 days from now
+	card := Card{Number: "4012888888881881", ExpiryMonth: 12, ExpiryYear: 2020}
+	card.Apply(WithClock(func() time.Time { return time.Date(2020, time.December, 12, 0, 0, 0, 0, time.UTC) }))
+	assert.True(card.IsExpiringSoon(days(30)))
+	assert.True(card.IsExpiringSoon(days(20)))
+	assert.False(card.IsExpiringSoon(days(19)))
+
+	card.Apply(WithClock(func() time.Time { return time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC) }))
+	assert.False(card.IsExpiringSoon(days(30)))
+
+	card = Card{Number: "4012888888881881", ExpiryMonth: 13, ExpiryYear: 2020}
+	assert.False(card.IsExpiringSoon(days(30)))
+}
+
+func TestExpiryDate(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{Number: "4012888888881881", ExpiryMonth: 2, ExpiryYear: 2024}
+	expiry, err := card.ExpiryDate()
+	assert.NoError(err)
+	assert.Equal(time.Date(2024, time.February, 29, 23, 59, 59, 999999999, time.UTC), expiry)
+
+	card = Card{Number: "4012888888881881", ExpiryMonth: 13, ExpiryYear: 2024}
+	expiry, err = card.ExpiryDate()
+	assert.True(errors.Is(err, ErrInvalidMonth))
+	assert.EqualError(err, "month '13' is not a valid month")
+	assert.True(expiry.IsZero())
+
+	card = Card{Number: "4012888888881881", ExpiryMonth: 12, ExpiryYear: 0}
+	_, err = card.ExpiryDate()
+	assert.True(errors.Is(err, ErrInvalidYear))
+}
+
+func TestTwoDigitExpiryYear(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC) })
+
+	card := NewCard("4012888888881881", WithExpiry(12, 25), WithCVV("123"), clock)
+	val := card.Validate()
+	assert.True(val.ValidExpiryYear)
+	assert.False(val.IsExpired)
+	assert.True(val.Valid())
+	assert.Equal(2025, val.Card.ExpiryYear)
+	assert.Equal(25, card.ExpiryYear)
+
+	expiry, err := card.ExpiryDate()
+	assert.NoError(err)
+	assert.Equal(2025, expiry.Year())
+
+	// Years more than 20 years ahead belong to the previous century
+	card.ExpiryYear = 50
+	val = card.Validate()
+	assert.Equal(1950, val.Card.ExpiryYear)
+	assert.False(val.ValidExpiryYear)
+	assert.True(val.IsExpired)
+}
+
+func TestValidateExpiry(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC)
+
+	validMonth, validYear, expired := ValidateExpiry(11, 2020, now)
+	assert.True(validMonth)
+	assert.True(validYear)
+	assert.False(expired)
+
+	validMonth, validYear, expired = ValidateExpiry(10, 20, now)
+	assert.True(validMonth)
+	assert.True(validYear)
+	assert.False(expired)
+
+	validMonth, validYear, expired = ValidateExpiry(13, 2020, now)
+	assert.False(validMonth)
+	assert.True(validYear)
+	assert.True(expired)
+
+	// A year too far ahead is not valid, but the card is not expired
+	validMonth, validYear, expired = ValidateExpiry(11, 2041, now)
+	assert.True(validMonth)
+	assert.False(validYear)
+	assert.False(expired)
+
+	validMonth, validYear, expired = ValidateExpiry(1, 2050, time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC))
+	assert.True(validMonth)
+	assert.False(validYear)
+	assert.False(expired)
+
+	validMonth, validYear, expired = ValidateExpiry(9, 2020, now)
+	assert.True(validMonth)
+	assert.True(validYear)
+	assert.True(expired)
+}
+
+func TestExpiryString(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{ExpiryMonth: 1, ExpiryYear: 2025}
+	assert.Equal("01/25", card.ExpiryString())
+
+	card = Card{ExpiryMonth: 11, ExpiryYear: 2030}
+	assert.Equal("11/30", card.ExpiryString())
+
+	card = Card{ExpiryMonth: 12, ExpiryYear: 2005}
+	assert.Equal("12/05", card.ExpiryString())
+
+	card = Card{ExpiryMonth: 3, ExpiryYear: 27}
+	assert.Equal("03/27", card.ExpiryString())
+}
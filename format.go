@@ -0,0 +1,42 @@
+package creditcard
+
+import "strings"
+
+// digitGroups contains the grouping of the digits of card types that don't use groups of four digits
+var digitGroups = map[CardType][]int{
+	AmericanExpress:         {4, 6, 5},
+	DinersClubCarteBlanche:  {4, 6, 5},
+	DinersClubEnroute:       {4, 6, 5},
+	DinersClubInternational: {4, 6, 4},
+}
+
+// Format returns the card number with the digits grouped the way they are printed on the card, based on the
+// detected card type. For example, American Express numbers are grouped as 4-6-5 while most other card types
+// use groups of four digits. Unknown card types are grouped in groups of four digits
+func (c *Card) Format() string {
+	number := c.number()
+	if len(number) == 0 {
+		return ""
+	}
+
+	cardType, _ := c.determineCardType()
+	groups := digitGroups[cardType]
+
+	var sb strings.Builder
+	for i := 0; i < len(number); {
+		size := 4
+		if len(groups) > 0 {
+			size, groups = groups[0], groups[1:]
+		}
+		if i+size > len(number) {
+			size = len(number) - i
+		}
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(number[i : i+size])
+		i += size
+	}
+
+	return sb.String()
+}
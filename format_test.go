@@ -0,0 +1,32 @@
+package creditcard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{Number: "378282246310005"}
+	assert.Equal("3782 822463 10005", card.Format())
+
+	card = Card{Number: "30569309025904"}
+	assert.Equal("3056 930902 5904", card.Format())
+
+	card = Card{Number: "4012888888881881"}
+	assert.Equal("4012 8888 8888 1881", card.Format())
+
+	card = Card{Number: "4012-8888-8888-1881"}
+	assert.Equal("4012 8888 8888 1881", card.Format())
+
+	card = Card{Number: "4012888888881881123"}
+	assert.Equal("4012 8888 8888 1881 123", card.Format())
+
+	card = Card{Number: "0000000000"}
+	assert.Equal("0000 0000 00", card.Format())
+
+	card = Card{}
+	assert.Equal("", card.Format())
+}
@@ -0,0 +1,147 @@
+package creditcard
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// numberTemplate describes how a number for a card type is generated
+type numberTemplate struct {
+	// prefix is a representative IIN prefix of the card type
+	prefix string
+	// length is the total length of the generated number, including the check digit
+	length int
+}
+
+// numberTemplates contains the templates used to generate numbers for each card type
+var numberTemplates = map[CardType]numberTemplate{
+	AmericanExpress:         {prefix: "378282", length: 15},
+	Aura:                    {prefix: "507860", length: 16},
+	Bankcard:                {prefix: "560221", length: 16},
+	Cabal:                   {prefix: "604201", length: 16},
+	ChinaUnionPay:           {prefix: "620000", length: 16},
+	Dankort:                 {prefix: "501971", length: 16},
+	DinersClubCarteBlanche:  {prefix: "300000", length: 15},
+	DinersClubEnroute:       {prefix: "201400", length: 15},
+	DinersClubInternational: {prefix: "360000", length: 14},
+	Discover:                {prefix: "601100", length: 16},
+	Elo:                     {prefix: "636368", length: 16},
+	Hipercard:               {prefix: "606282", length: 16},
+	Humo:                    {prefix: "986001", length: 16},
+	InstaPayment:            {prefix: "637100", length: 16},
+	InterPayment:            {prefix: "636100", length: 16},
+	JCB:                     {prefix: "353011", length: 16},
+	Maestro:                 {prefix: "675900", length: 16},
+	Mastercard:              {prefix: "555555", length: 16},
+	Mir:                     {prefix: "220012", length: 16},
+	RuPay:                   {prefix: "608100", length: 16},
+	Troy:                    {prefix: "979200", length: 16},
+	UATP:                    {prefix: "100000", length: 15},
+	UzCard:                  {prefix: "860001", length: 16},
+	Verve:                   {prefix: "506100", length: 16},
+	Visa:                    {prefix: "401288", length: 16},
+	VisaElectron:            {prefix: "491700", length: 16},
+}
+
+// GenerateNumber generates a random card number for the given card type, which passes the Luhn algorithm.
+// The generated numbers are meant for testing and don't belong to actual cards. An optional seed can be
+// passed to generate the same number on every call
+func GenerateNumber(t CardType, seed ...int64) (string, error) {
+	s := time.Now().UnixNano()
+	if len(seed) > 0 {
+		s = seed[0]
+	}
+	return generateNumber(t, rand.New(rand.NewSource(s)))
+}
+
+// generateNumber generates a card number for the given card type using the random number generator
+func generateNumber(t CardType, rnd *rand.Rand) (string, error) {
+	tmpl, ok := numberTemplates[t]
+	if !ok {
+		return "", fmt.Errorf("cannot generate a number for card type '%s'", t)
+	}
+
+	number := make([]byte, 0, tmpl.length)
+	number = append(number, tmpl.prefix...)
+	for len(number) < tmpl.length-1 {
+		number = append(number, byte('0'+rnd.Intn(10)))
+	}
+
+	digit, err := GenerateCheckDigit(string(number))
+	if err != nil {
+		return "", err
+	}
+
+	return string(number) + strconv.Itoa(digit), nil
+}
+
+// GenerateNumbers generates the given number of distinct card numbers for the given card type, which all pass the
+// Luhn algorithm. The same seed always results in the same numbers. An error is returned when the card type has
+// fewer possible numbers than requested
+func GenerateNumbers(t CardType, count int, seed int64) ([]string, error) {
+	tmpl, ok := numberTemplates[t]
+	if !ok {
+		return nil, fmt.Errorf("cannot generate a number for card type '%s'", t)
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("count '%d' is not a valid number of card numbers", count)
+	}
+
+	// The digits between the prefix and the check digit are random,
+	// which limits the number of distinct numbers that can be generated
+	space := 1
+	for i := len(tmpl.prefix); i < tmpl.length-1 && space <= count; i++ {
+		space *= 10
+	}
+	if count > space {
+		return nil, fmt.Errorf("cannot generate %d distinct numbers for card type '%s'", count, t)
+	}
+
+	rnd := rand.New(rand.NewSource(seed))
+	numbers := make([]string, 0, count)
+	seen := make(map[string]bool, count)
+	for len(numbers) < count {
+		number, err := generateNumber(t, rnd)
+		if err != nil {
+			return nil, err
+		}
+		if seen[number] {
+			continue
+		}
+		seen[number] = true
+		numbers = append(numbers, number)
+	}
+
+	return numbers, nil
+}
+
+// GenerateExpiredCard generates a card of the given card type with a valid number and CVV, which expired at the
+// end of the month before the current time of the clock, for example to test how expired cards are handled. The
+// clock defaults to time.Now when it's nil, and is also used by the card to check whether it's expired. Nil is
+// returned for card types that numbers can't be generated for
+func GenerateExpiredCard(t CardType, clock func() time.Time) *Card {
+	if clock == nil {
+		clock = time.Now
+	}
+	now := clock()
+
+	number, err := GenerateNumber(t, now.UnixNano())
+	if err != nil {
+		return nil
+	}
+
+	// The first day of the previous month, since adding -1 month to the 31st can stay in the same month
+	expiry := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, now.Location())
+
+	return NewCard(number,
+		WithType(t),
+		WithExpiry(int(expiry.Month()), expiry.Year()),
+		WithCVV(strings.Repeat("1", cvvLengths(t)[0])),
+		WithClock(clock),
+		// In January, the card expired in the previous year, which is not an accepted expiry year by default
+		WithExpiryYearRange(expiry.Year(), now.Year()+defaultExpiryYears),
+	)
+}
@@ -0,0 +1,110 @@
+package creditcard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	for cardType := AmericanExpress; int(cardType) < len(cardTypeNames); cardType++ {
+		number, err := GenerateNumber(cardType)
+		assert.NoError(err)
+
+		card := Card{Number: number}
+		detected, err := card.DetectType()
+		assert.NoError(err)
+		assert.Equal(cardType, detected, number)
+		assert.True(card.validateLuhn(), number)
+	}
+
+	first, err := GenerateNumber(Visa, 42)
+	assert.NoError(err)
+	second, err := GenerateNumber(Visa, 42)
+	assert.NoError(err)
+	assert.Equal(first, second)
+
+	_, err = GenerateNumber(Unknown)
+	assert.EqualError(err, "cannot generate a number for card type 'Unknown Card'")
+}
+
+func TestGenerateNumbers(t *testing.T) {
+	assert := assert.New(t)
+
+	numbers, err := GenerateNumbers(Mastercard, 500, 42)
+	assert.NoError(err)
+	assert.Len(numbers, 500)
+
+	seen := make(map[string]bool)
+	for _, number := range numbers {
+		assert.False(seen[number], number)
+		seen[number] = true
+
+		card := Card{Number: number}
+		cardType, err := card.DetectType()
+		assert.NoError(err)
+		assert.Equal(Mastercard, cardType)
+		assert.True(card.validateLuhn())
+	}
+
+	again, err := GenerateNumbers(Mastercard, 500, 42)
+	assert.NoError(err)
+	assert.Equal(numbers, again)
+
+	// Diners Club International numbers have 14 digits, which leaves 7 random digits
+	numbers, err = GenerateNumbers(DinersClubInternational, 10, 1)
+	assert.NoError(err)
+	assert.Len(numbers, 10)
+	_, err = GenerateNumbers(DinersClubInternational, 10000001, 1)
+	assert.Error(err)
+
+	numbers, err = GenerateNumbers(Visa, 0, 1)
+	assert.NoError(err)
+	assert.Empty(numbers)
+
+	_, err = GenerateNumbers(Visa, -1, 1)
+	assert.Error(err)
+
+	_, err = GenerateNumbers(Unknown, 1, 1)
+	assert.Error(err)
+}
+
+func TestGenerateExpiredCard(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, now := range []time.Time{
+		time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, time.March, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		clock := func() time.Time { return now }
+		expiry := now.AddDate(0, 0, -now.Day())
+
+		for cardType := AmericanExpress; int(cardType) < len(cardTypeNames); cardType++ {
+			card := GenerateExpiredCard(cardType, clock)
+			if !assert.NotNil(card, cardType.String()) {
+				continue
+			}
+			assert.Equal(int(expiry.Month()), card.ExpiryMonth, now)
+			assert.Equal(expiry.Year(), card.ExpiryYear, now)
+
+			val := card.Validate()
+			assert.True(val.IsExpired, card.Number)
+			assert.True(val.ValidCardNumber, card.Number)
+			assert.True(val.ValidExpiryMonth, card.Number)
+			assert.True(val.ValidExpiryYear, card.Number)
+			assert.True(val.ValidCVV, card.Number)
+			assert.Equal(cardType, val.DetectedType, card.Number)
+			assert.Equal([]string{"creditcard is expired"}, val.Errors, card.Number)
+		}
+	}
+
+	card := GenerateExpiredCard(Visa, nil)
+	assert.NotNil(card)
+	assert.True(card.Validate().IsExpired)
+
+	assert.Nil(GenerateExpiredCard(Unknown, nil))
+}
@@ -0,0 +1,45 @@
+package creditcard
+
+import "encoding/json"
+
+// cardJSON is the JSON representation of a card
+type cardJSON struct {
+	Type        string
+	Number      string
+	ExpiryMonth int
+	ExpiryYear  int
+	CVV         string `json:",omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. By default, all but the last four digits of the
+// card number are masked and the CVV is left out, so a card can safely be used in API responses and logs.
+// Cards configured with WithUnmaskedJSON include the full card number and CVV
+func (c Card) MarshalJSON() ([]byte, error) {
+	card := cardJSON{
+		Type:        c.Type,
+		Number:      c.MaskNumber(),
+		ExpiryMonth: c.ExpiryMonth,
+		ExpiryYear:  c.ExpiryYear,
+	}
+	if c.opts.unmaskedJSON {
+		card.Number = c.Number
+		card.CVV = c.CVV
+	}
+
+	return json.Marshal(card)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface and accepts the full card number and CVV
+func (c *Card) UnmarshalJSON(data []byte) error {
+	var card cardJSON
+	if err := json.Unmarshal(data, &card); err != nil {
+		return err
+	}
+
+	c.Type = card.Type
+	c.Number = card.Number
+	c.ExpiryMonth = card.ExpiryMonth
+	c.ExpiryYear = card.ExpiryYear
+	c.CVV = card.CVV
+	return nil
+}
@@ -0,0 +1,44 @@
+package creditcard
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{
+		Type: "Visa", Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	b, err := json.Marshal(card)
+	assert.NoError(err)
+	assert.JSONEq(`{"Type":"Visa","Number":"************1881","ExpiryMonth":11,"ExpiryYear":2020}`, string(b))
+
+	b, err = json.Marshal(card.Validate())
+	assert.NoError(err)
+	assert.NotContains(string(b), "4012888888881881")
+	assert.Contains(string(b), "************1881")
+
+	card.Apply(WithUnmaskedJSON())
+	b, err = json.Marshal(&card)
+	assert.NoError(err)
+	assert.JSONEq(`{"Type":"Visa","Number":"4012888888881881","ExpiryMonth":11,"ExpiryYear":2020,"CVV":"123"}`, string(b))
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	var card Card
+	err := json.Unmarshal([]byte(`{"Type":"Visa","Number":"4012888888881881","ExpiryMonth":11,"ExpiryYear":2020,"CVV":"123"}`), &card)
+	assert.NoError(err)
+	assert.Equal("Visa", card.Type)
+	assert.Equal("4012888888881881", card.Number)
+	assert.Equal(11, card.ExpiryMonth)
+	assert.Equal(2020, card.ExpiryYear)
+	assert.Equal("123", card.CVV)
+
+	assert.Error(json.Unmarshal([]byte(`{"Number":4012888888881881}`), &card))
+}
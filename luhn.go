@@ -0,0 +1,106 @@
+package creditcard
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Luhn reports whether the number passes the Luhn algorithm. Spaces and dashes are ignored, and numbers
+// that are empty or contain other characters than digits don't pass. Unlike the validation of a card,
+// the length of the number is not checked, so it can be used for any number that uses a Luhn check digit
+func Luhn(number string) bool {
+	valid, _ := LuhnValid(number)
+	return valid
+}
+
+// LuhnValid reports whether the number passes the Luhn algorithm, like Luhn, and returns
+// an error when the number is empty or contains other characters than digits
+func LuhnValid(number string) (bool, error) {
+	sum, err := LuhnSum(number)
+	if err != nil {
+		return false, err
+	}
+
+	return sum%10 == 0, nil
+}
+
+// LuhnSum returns the sum of the digits of the number according to the Luhn algorithm, before the sum is checked to
+// be divisible by 10. Starting from the check digit, every second digit is doubled, and 9 is subtracted from doubled
+// digits above 9. Spaces and dashes are ignored, and an error is returned when the number is empty or contains other
+// characters than digits
+func LuhnSum(number string) (int, error) {
+	number = separators.Replace(number)
+	if len(number) == 0 {
+		return 0, fmt.Errorf("number is empty")
+	}
+
+	return luhnSum(number, false)
+}
+
+// GenerateCheckDigit calculates the Luhn check digit for a number that is missing its last digit.
+// Appending the returned digit to the partial number results in a number that passes the Luhn algorithm
+func GenerateCheckDigit(partial string) (int, error) {
+	partial = separators.Replace(partial)
+	if len(partial) == 0 {
+		return 0, fmt.Errorf("partial number is empty")
+	}
+
+	// The check digit will be appended to the right, so the
+	// rightmost digit of the partial number has to be doubled
+	sum, err := luhnSum(partial, true)
+	if err != nil {
+		return 0, err
+	}
+
+	return (10 - sum%10) % 10, nil
+}
+
+// WithValidCheckDigit returns the card number with the last digit replaced by the Luhn check digit, so the
+// number passes the Luhn algorithm while the BIN stays the same. This turns a hand-typed number into a valid
+// one for testing. Spaces and dashes are removed, and an error is returned for non-digits or numbers that are too
+// short, where the minimum length depends on the card type, like CheckLuhn
+func (c *Card) WithValidCheckDigit() (string, error) {
+	number := c.number()
+	if err := checkDigits(number); err != nil {
+		return "", err
+	}
+	if len(number) < c.minNumberLength() {
+		return "", newError(ErrInvalidLength, "length %d is too short for a card number", len(number))
+	}
+
+	partial := number[:len(number)-1]
+	digit, err := GenerateCheckDigit(partial)
+	if err != nil {
+		return "", err
+	}
+	return partial + strconv.Itoa(digit), nil
+}
+
+// luhnSum calculates the sum of the digits of the number according to the Luhn algorithm.
+// Starting from the rightmost digit, every second digit is doubled. If doubleFirst is set,
+// the doubling starts with the rightmost digit itself
+func luhnSum(number string, doubleFirst bool) (int, error) {
+	var sum int
+	alternate := doubleFirst
+
+	// Parse all numbers of the card into a for loop
+	for i := len(number) - 1; i > -1; i-- {
+		// Takes the mod, converting the current number in integer.
+		// Anything that isn't a digit makes the number invalid
+		if number[i] < '0' || number[i] > '9' {
+			return 0, fmt.Errorf("number contains non-digit characters")
+		}
+		mod := int(number[i] - '0')
+		if alternate {
+			mod *= 2
+			if mod > 9 {
+				mod = (mod % 10) + 1
+			}
+		}
+
+		alternate = !alternate
+		sum += mod
+	}
+
+	return sum, nil
+}
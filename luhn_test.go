@@ -0,0 +1,167 @@
+package creditcard
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateCheckDigit(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, partial := range []string{"401288888888188", "37828224631000", "555555555555444", "601111111111111", "3056930009020"} {
+		digit, err := GenerateCheckDigit(partial)
+		assert.NoError(err)
+
+		card := Card{Number: partial + strconv.Itoa(digit)}
+		assert.True(card.validateLuhn(), card.Number)
+	}
+
+	digit, err := GenerateCheckDigit("401288888888188")
+	assert.NoError(err)
+	assert.Equal(1, digit)
+
+	_, err = GenerateCheckDigit("4012abcd8888188")
+	assert.Error(err)
+
+	_, err = GenerateCheckDigit("")
+	assert.Error(err)
+}
+
+func TestWithValidCheckDigit(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, number := range []string{"4012888888881882", "4012 8888 8888 1881", "378282246310009", "6011000000000000000"} {
+		card := Card{Number: number}
+		fixed, err := card.WithValidCheckDigit()
+		assert.NoError(err, number)
+		assert.Equal(card.number()[:len(card.number())-1], fixed[:len(fixed)-1], number)
+
+		card = Card{Number: fixed}
+		assert.True(card.validateLuhn(), fixed)
+	}
+
+	card := Card{Number: "4012888888881882"}
+	fixed, err := card.WithValidCheckDigit()
+	assert.NoError(err)
+	assert.Equal("4012888888881881", fixed)
+	assert.Equal("4012888888881882", card.Number)
+
+	card = Card{Number: "4012abcd88881882"}
+	_, err = card.WithValidCheckDigit()
+	assert.True(errors.Is(err, ErrNonDigit))
+
+	card = Card{Number: "401288888888"}
+	_, err = card.WithValidCheckDigit()
+	assert.True(errors.Is(err, ErrInvalidLength))
+
+	// Maestro allows numbers of 12 digits
+	card = Card{Number: "675964982640"}
+	fixed, err = card.WithValidCheckDigit()
+	assert.NoError(err)
+	assert.Equal("67596498264", fixed[:11])
+	card = Card{Number: fixed}
+	assert.NoError(card.CheckLuhn())
+
+	card = Card{Number: "67596498264"}
+	_, err = card.WithValidCheckDigit()
+	assert.True(errors.Is(err, ErrInvalidLength))
+}
+
+func TestLuhn(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, number := range []string{"4012888888881881", "378282246310005", "4012 8888 8888 1881", "490154203237518", "0"} {
+		assert.True(Luhn(number), number)
+		valid, err := LuhnValid(number)
+		assert.NoError(err)
+		assert.True(valid, number)
+	}
+
+	for _, number := range []string{"4012888888881882", "378282246310006", "490154203237519", "1"} {
+		assert.False(Luhn(number), number)
+		valid, err := LuhnValid(number)
+		assert.NoError(err)
+		assert.False(valid, number)
+	}
+
+	for _, number := range []string{"", "4012abcd88881881"} {
+		assert.False(Luhn(number), number)
+		valid, err := LuhnValid(number)
+		assert.Error(err)
+		assert.False(valid, number)
+	}
+}
+
+func TestCheckLuhn(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{Number: "4012888888881881"}
+	assert.NoError(card.CheckLuhn())
+
+	card = Card{Number: "4012888888"}
+	err := card.CheckLuhn()
+	assert.True(errors.Is(err, ErrLengthOutOfRange))
+	assert.EqualError(err, "length 10 out of range 13 to 19")
+	assert.False(errors.Is(err, ErrChecksumFailed))
+
+	card = Card{Number: "4012888888881882"}
+	err = card.CheckLuhn()
+	assert.True(errors.Is(err, ErrChecksumFailed))
+	assert.False(errors.Is(err, ErrLengthOutOfRange))
+
+	val := card.Validate()
+	assert.True(val.HasError(ErrChecksumFailed))
+	assert.True(val.HasError(ErrInvalidNumber))
+	assert.Contains(val.Errors, "checksum failed")
+}
+
+func TestCheckLuhnMaestro(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, number := range []string{"675900000018", "5018 0000 0009"} {
+		card := Card{Number: number, ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123"}
+		card.Apply(WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) }))
+		assert.NoError(card.CheckLuhn(), number)
+
+		val := card.Validate()
+		assert.Equal(Maestro, val.DetectedType, number)
+		assert.True(val.ValidCardNumber, number)
+		assert.True(val.Valid(), number)
+	}
+
+	// Other card types still require at least 13 digits
+	card := Card{Number: "401288888884"}
+	assert.True(errors.Is(card.CheckLuhn(), ErrLengthOutOfRange))
+
+	card = Card{Number: "67590000001"}
+	assert.EqualError(card.CheckLuhn(), "length 11 out of range 12 to 19")
+}
+
+func TestLuhnSum(t *testing.T) {
+	assert := assert.New(t)
+
+	// 7 9 9 2 7 3 9 8 7 1 3 with every second digit from the right doubled: 7+9+9+4+7+6+9+7+7+2+3
+	sum, err := LuhnSum("79927398713")
+	assert.NoError(err)
+	assert.Equal(70, sum)
+
+	for _, number := range []string{"4012888888881881", "378282246310005", "4012 8888 8888 1881"} {
+		sum, err := LuhnSum(number)
+		assert.NoError(err)
+		assert.Zero(sum%10, number)
+	}
+
+	sum, err = LuhnSum("4012888888881882")
+	assert.NoError(err)
+	assert.NotZero(sum % 10)
+
+	_, err = LuhnSum("")
+	assert.Error(err)
+
+	_, err = LuhnSum("4012abcd")
+	assert.Error(err)
+}
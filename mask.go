@@ -0,0 +1,132 @@
+package creditcard
+
+import (
+	"regexp"
+	"strings"
+	"sync/atomic"
+)
+
+// defaultMask is the character used to mask the digits of a card number, unless configured otherwise
+const defaultMask = '*'
+
+// defaultMaskRune is the mask character used by cards without their own mask character. It's
+// accessed atomically, so it can be changed while other goroutines mask card numbers
+var defaultMaskRune int32 = defaultMask
+
+// SetDefaultMaskRune sets the character used to mask the digits of card numbers for all cards that don't have
+// their own mask character set with WithMaskRune. It is safe to call while other goroutines mask card numbers
+func SetDefaultMaskRune(mask rune) {
+	atomic.StoreInt32(&defaultMaskRune, mask)
+}
+
+// MaskNumber returns the card number with all but the last four digits replaced by '*', or the mask character
+// configured with WithMaskRune or SetDefaultMaskRune. Spaces and dashes are removed before masking. Numbers of
+// four digits or less are masked completely, so the full number is never revealed
+func (c *Card) MaskNumber() string {
+	return c.MaskNumberWith(c.maskRune())
+}
+
+// maskRune returns the mask character of the card, which defaults to the package-wide mask character
+func (c *Card) maskRune() rune {
+	if c.opts.maskRune != 0 {
+		return c.opts.maskRune
+	}
+	return atomic.LoadInt32(&defaultMaskRune)
+}
+
+// MaskNumberWith returns the card number with all but the last four digits replaced by the mask character.
+// Spaces and dashes are removed before masking. Numbers of four digits or less are masked completely
+func (c *Card) MaskNumberWith(mask rune) string {
+	return maskNumber(c.number(), mask)
+}
+
+// maskNumber replaces all but the last four characters of the number with the mask character. Characters are
+// counted as runes, so numbers with non-ASCII digits keep their length and remain valid UTF-8
+func maskNumber(number string, mask rune) string {
+	runes := []rune(number)
+	masked := len(runes) - 4
+	if masked <= 0 {
+		masked = len(runes)
+	}
+
+	return strings.Repeat(string(mask), masked) + string(runes[masked:])
+}
+
+// AnonymizedCopy returns a copy of the card that is safe to log or to pass to less trusted code. The number of the copy
+// is masked like MaskNumber does, and the CVV is removed, while the card type, expiry date, and options are kept. The
+// original card is not changed. The copy can't be validated, since the full card number is no longer available
+func (c *Card) AnonymizedCopy() *Card {
+	card := *c
+	card.Number = c.MaskNumber()
+	card.CVV = ""
+	return &card
+}
+
+// Last4 returns the last four digits of the card number, after removing spaces and dashes.
+// The whole number is returned when it is shorter than four digits
+func (c *Card) Last4() string {
+	number := c.number()
+	if len(number) < 4 {
+		return number
+	}
+	return number[len(number)-4:]
+}
+
+// digitRuns matches runs of digits that are grouped with single spaces or dashes, like card numbers often are
+var digitRuns = regexp.MustCompile(`[0-9]+(?:[ -][0-9]+)*`)
+
+// digitGroup matches a group of digits within a run of digits
+var digitGroup = regexp.MustCompile(`[0-9]+`)
+
+// Scrub masks all card numbers in the text, for example to prevent card numbers from ending up in logs. Card numbers
+// are sequences of 13 to 19 digits, which may be grouped with spaces or dashes, that pass the Luhn algorithm. All but
+// the last four digits are replaced by the package-wide mask character, while the spaces and dashes are kept
+func Scrub(text string) string {
+	mask := atomic.LoadInt32(&defaultMaskRune)
+	return digitRuns.ReplaceAllStringFunc(text, func(run string) string {
+		return scrubRun(run, mask)
+	})
+}
+
+// scrubRun masks the card numbers in a run of digit groups. A run might contain more than just a card number,
+// like the expiry date that follows it, so the longest sequence of groups that forms a card number is masked
+func scrubRun(run string, mask rune) string {
+	groups := digitGroup.FindAllStringIndex(run, -1)
+
+	var sb strings.Builder
+	last := 0
+	for i := 0; i < len(groups); i++ {
+		for j := len(groups) - 1; j >= i; j-- {
+			start, end := groups[i][0], groups[j][1]
+			number := separators.Replace(run[start:end])
+			if len(number) < minNumberLength || len(number) > maxNumberLength || !Luhn(number) {
+				continue
+			}
+
+			sb.WriteString(run[last:start])
+			sb.WriteString(maskDigits(run[start:end], mask))
+			last = end
+			i = j
+			break
+		}
+	}
+	sb.WriteString(run[last:])
+	return sb.String()
+}
+
+// maskDigits replaces all but the last four digits with the mask character, keeping any other characters
+func maskDigits(s string, mask rune) string {
+	visible := 4
+	runes := []rune(s)
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] < '0' || runes[i] > '9' {
+			continue
+		}
+		if visible > 0 {
+			visible--
+			continue
+		}
+		runes[i] = mask
+	}
+	return string(runes)
+}
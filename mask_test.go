@@ -0,0 +1,129 @@
+package creditcard
+
+import (
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskNumber(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{Number: "4012888888881881"}
+	assert.Equal("************1881", card.MaskNumber())
+	assert.Equal("XXXXXXXXXXXX1881", card.MaskNumberWith('X'))
+	assert.Equal("••••••••••••1881", card.MaskNumberWith('•'))
+
+	card = Card{Number: "4012 8888 8888 1881"}
+	assert.Equal("************1881", card.MaskNumber())
+
+	card = Card{Number: "378282246310005"}
+	assert.Equal("***********0005", card.MaskNumber())
+
+	card = Card{Number: "123"}
+	assert.Equal("***", card.MaskNumber())
+
+	card = Card{Number: "1234"}
+	assert.Equal("****", card.MaskNumber())
+
+	card = Card{}
+	assert.Equal("", card.MaskNumber())
+
+	// Non-ASCII digits are masked per character
+	card = Card{Number: "４０１２８８８８８８８８１８８１"}
+	masked := card.MaskNumber()
+	assert.Equal("************１８８１", masked)
+	assert.True(utf8.ValidString(masked))
+	assert.Equal(utf8.RuneCountInString(card.Number), utf8.RuneCountInString(masked))
+
+	card = Card{Number: "１２３"}
+	assert.Equal("***", card.MaskNumber())
+}
+
+func TestMaskRune(t *testing.T) {
+	assert := assert.New(t)
+
+	card := NewCard("4012888888881881", WithMaskRune('•'))
+	assert.Equal("••••••••••••1881", card.MaskNumber())
+	assert.Equal("XXXXXXXXXXXX1881", card.MaskNumberWith('X'))
+
+	SetDefaultMaskRune('X')
+	defer SetDefaultMaskRune(defaultMask)
+
+	other := Card{Number: "4012888888881881"}
+	assert.Equal("XXXXXXXXXXXX1881", other.MaskNumber())
+	assert.Equal("XXXXXXXXXXXX1881", other.Validate().Card.MaskNumber())
+
+	// The mask character of the card takes precedence
+	assert.Equal("••••••••••••1881", card.MaskNumber())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetDefaultMaskRune('X')
+		}()
+		go func() {
+			defer wg.Done()
+			assert.Equal("XXXXXXXXXXXX1881", other.MaskNumber())
+		}()
+	}
+	wg.Wait()
+}
+
+func TestAnonymizedCopy(t *testing.T) {
+	assert := assert.New(t)
+
+	card := NewCard("4012 8888 8888 1881", WithExpiry(11, 2030), WithCVV("123"), WithType(Visa))
+	anonymized := card.AnonymizedCopy()
+	assert.Equal("************1881", anonymized.Number)
+	assert.Empty(anonymized.CVV)
+	assert.Equal("Visa", anonymized.Type)
+	assert.Equal(11, anonymized.ExpiryMonth)
+	assert.Equal(2030, anonymized.ExpiryYear)
+
+	// The original card is not changed
+	assert.Equal("4012 8888 8888 1881", card.Number)
+	assert.Equal("123", card.CVV)
+
+	card.Apply(WithMaskRune('X'))
+	assert.Equal("XXXXXXXXXXXX1881", card.AnonymizedCopy().Number)
+}
+
+func TestLast4(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{Number: "4012888888881881"}
+	assert.Equal("1881", card.Last4())
+
+	card = Card{Number: "4012-8888-8888-18 81"}
+	assert.Equal("1881", card.Last4())
+
+	card = Card{Number: "1234"}
+	assert.Equal("1234", card.Last4())
+
+	card = Card{Number: "12"}
+	assert.Equal("12", card.Last4())
+
+	card = Card{}
+	assert.Equal("", card.Last4())
+}
+
+func TestScrub(t *testing.T) {
+	assert := assert.New(t)
+
+	for text, expected := range map[string]string{
+		"payment failed for card 4012888888881881 (visa)":      "payment failed for card ************1881 (visa)",
+		"card=4012 8888 8888 1881 exp=11/20":                   "card=**** **** **** 1881 exp=11/20",
+		"card 4012-8888-8888-1881 12 2020":                     "card ****-****-****-1881 12 2020",
+		"amex 3782 822463 10005, visa 4111111111111111":        "amex **** ****** *0005, visa ************1111",
+		"order 4012888888881882 is not a card":                 "order 4012888888881882 is not a card",
+		"short 401288888 and long 40128888888818811234567 ids": "short 401288888 and long 40128888888818811234567 ids",
+		"2020-10-15 12:00:00 nothing to see":                   "2020-10-15 12:00:00 nothing to see",
+	} {
+		assert.Equal(expected, Scrub(text), text)
+	}
+}
@@ -0,0 +1,163 @@
+package creditcard
+
+import "time"
+
+// Option is a function that configures optional behavior of a card
+type Option func(*Card)
+
+// options contains the optional configuration of a card
+type options struct {
+	// now returns the current time, which defaults to time.Now
+	now func() time.Time
+	// maskValue masks the card number when the card is stored in a database
+	maskValue bool
+	// unmaskedJSON includes the full card number and CVV when the card is marshaled to JSON
+	unmaskedJSON bool
+	// binLength is the number of digits of the BIN, which defaults to 6
+	binLength int
+	// expiryYearRange is the range of accepted expiry years, which defaults to the current year up to 20 years ahead
+	expiryYearRange *[2]int
+	// skipTypeDetection trusts the given card type instead of determining the card type from the number
+	skipTypeDetection bool
+	// maskRune is the character used to mask the card number, which defaults to the package-wide mask character
+	maskRune rune
+	// acceptedTypes are the card types that are accepted by Validate, where nil accepts all card types
+	acceptedTypes []CardType
+	// cvvOptional accepts cards without a CVV, for example for card-on-file or recurring transactions
+	cvvOptional bool
+	// expiryGrace is the period after the end of the expiry month during which the card is not yet expired
+	expiryGrace time.Duration
+	// expectedCVVLength overrides the CVV length of the card type when it's more than 0
+	expectedCVVLength int
+}
+
+// NewCard creates a card with the given number and applies the options to it
+func NewCard(number string, opts ...Option) *Card {
+	c := &Card{
+		Number: number,
+	}
+	c.Apply(opts...)
+	return c
+}
+
+// Apply applies the given options to the card
+func (c *Card) Apply(opts ...Option) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithExpiry sets the expiry month and year of the card
+func WithExpiry(month, year int) Option {
+	return func(c *Card) {
+		c.ExpiryMonth = month
+		c.ExpiryYear = year
+	}
+}
+
+// WithCVV sets the CVV of the card
+func WithCVV(cvv string) Option {
+	return func(c *Card) {
+		c.CVV = cvv
+	}
+}
+
+// WithType sets the expected card type, which is validated against the card type determined from the card number
+func WithType(cardType CardType) Option {
+	return func(c *Card) {
+		c.Type = cardType.String()
+	}
+}
+
+// WithClock sets the function used to determine the current time when checking whether a card is expired.
+// This is useful for testing, or to validate cards against a time in a different time zone
+func WithClock(now func() time.Time) Option {
+	return func(c *Card) {
+		c.opts.now = now
+	}
+}
+
+// WithMaskedValue masks all but the last four digits of the card number when the card is stored in a database
+// through the driver.Valuer interface, so the full card number is never persisted. Cards stored this way can't be
+// validated after they are loaded
+func WithMaskedValue() Option {
+	return func(c *Card) {
+		c.opts.maskValue = true
+	}
+}
+
+// WithUnmaskedJSON includes the full card number and the CVV when the card is marshaled to JSON.
+// This should only be used when the JSON document is sent to a trusted party
+func WithUnmaskedJSON() Option {
+	return func(c *Card) {
+		c.opts.unmaskedJSON = true
+	}
+}
+
+// WithBINLength sets the number of digits returned by BIN. The default is 6 digits, while
+// the newer standard for issuer identification numbers uses 8 digits
+func WithBINLength(length int) Option {
+	return func(c *Card) {
+		c.opts.binLength = length
+	}
+}
+
+// WithExpiryYearRange sets the range of accepted expiry years, including both min and max. By default
+// the current year up to 20 years from now are accepted
+func WithExpiryYearRange(min, max int) Option {
+	return func(c *Card) {
+		c.opts.expiryYearRange = &[2]int{min, max}
+	}
+}
+
+// WithTypeDetection sets whether the card type is determined from the card number when a card type is given, which
+// is the default. When disabled, the given card type is trusted, so it's never reported as a mismatch. Given card
+// types that aren't known, like display names from legacy data, are then validated without length or CVV rules
+// specific to the card type. Cards without a given card type are always detected from the card number
+func WithTypeDetection(enabled bool) Option {
+	return func(c *Card) {
+		c.opts.skipTypeDetection = !enabled
+	}
+}
+
+// WithMaskRune sets the character used to mask the digits of the card number, for example when the card is
+// marshaled to JSON or stored in a database. This overrides the package-wide mask character set with SetDefaultMaskRune
+func WithMaskRune(mask rune) Option {
+	return func(c *Card) {
+		c.opts.maskRune = mask
+	}
+}
+
+// WithAcceptedTypes limits the card types that are accepted, for merchants that only accept certain brands.
+// Validate reports cards of other card types, including cards with an unknown card type, as not accepted
+func WithAcceptedTypes(cardTypes ...CardType) Option {
+	return func(c *Card) {
+		c.opts.acceptedTypes = cardTypes
+	}
+}
+
+// WithCVVRequired sets whether a CVV is required, which is the default. Card-on-file and recurring transactions
+// often don't have a CVV, so Validate accepts cards without a CVV when it isn't required. A CVV that is given
+// is still validated
+func WithCVVRequired(required bool) Option {
+	return func(c *Card) {
+		c.opts.cvvOptional = !required
+	}
+}
+
+// WithExpiryGrace sets a grace period after the end of the expiry month during which the card is not reported as
+// expired yet, for processors that accept cards for a short period after the printed expiry date
+func WithExpiryGrace(grace time.Duration) Option {
+	return func(c *Card) {
+		c.opts.expiryGrace = grace
+	}
+}
+
+// WithExpectedCVVLength sets the length of the CVV that Validate expects, instead of the CVV length of the card type.
+// This is meant for issuers with non-standard requirements, when the expected length is known from the payment
+// service provider
+func WithExpectedCVVLength(length int) Option {
+	return func(c *Card) {
+		c.opts.expectedCVVLength = length
+	}
+}
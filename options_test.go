@@ -0,0 +1,253 @@
+package creditcard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithClock(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{
+		Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	card.Apply(WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) }))
+	val := card.Validate()
+	assert.False(val.IsExpired)
+	assert.NotContains(val.Errors, "creditcard is expired")
+
+	card.Apply(WithClock(func() time.Time { return time.Date(2020, time.December, 1, 0, 0, 0, 0, time.UTC) }))
+	val = card.Validate()
+	assert.True(val.IsExpired)
+	assert.Contains(val.Errors, "creditcard is expired")
+
+	card.Apply(WithClock(func() time.Time { return time.Date(2020, time.November, 1, 0, 0, 0, 0, time.UTC) }))
+	assert.False(card.isExpired())
+}
+
+func TestNewCard(t *testing.T) {
+	assert := assert.New(t)
+
+	card := NewCard("4012888888881881",
+		WithExpiry(11, 2020),
+		WithCVV("123"),
+		WithType(Visa),
+		WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) }),
+	)
+	assert.Equal("4012888888881881", card.Number)
+	assert.Equal(11, card.ExpiryMonth)
+	assert.Equal(2020, card.ExpiryYear)
+	assert.Equal("123", card.CVV)
+	assert.Equal("Visa", card.Type)
+
+	val := card.Validate()
+	assert.True(val.Valid())
+	assert.Empty(val.Errors)
+
+	card = NewCard("4012888888881881", WithType(AmericanExpress))
+	val = card.Validate()
+	assert.True(val.HasError(ErrTypeMismatch))
+
+	card = NewCard("4012888888881881")
+	assert.Empty(card.Type)
+	assert.Zero(card.ExpiryMonth)
+}
+
+func TestWithExpiryYearRange(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) })
+
+	// By default the current year up to 20 years from now are accepted
+	card := NewCard("4012888888881881", WithExpiry(12, 2040), WithCVV("123"), clock)
+	assert.True(card.Validate().ValidExpiryYear)
+	card.ExpiryYear = 2041
+	val := card.Validate()
+	assert.False(val.ValidExpiryYear)
+	assert.False(val.IsExpired)
+	assert.Equal([]string{CheckExpiryYear}, val.FailedChecks())
+	card.ExpiryYear = 2019
+	assert.False(card.Validate().ValidExpiryYear)
+
+	card = NewCard("4012888888881881", WithExpiry(12, 2025), WithCVV("123"), clock, WithExpiryYearRange(2020, 2025))
+	val = card.Validate()
+	assert.True(val.ValidExpiryYear)
+	assert.Empty(val.Errors)
+
+	card.ExpiryYear = 2026
+	val = card.Validate()
+	assert.False(val.ValidExpiryYear)
+	assert.True(val.HasError(ErrInvalidYear))
+	assert.Contains(val.Errors, "year '2026' is not a valid year")
+}
+
+func TestWithTypeDetection(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) })
+
+	// By default the given card type is compared against the detected card type
+	card := NewCard("4012888888881881", WithExpiry(11, 2020), WithCVV("123"), WithType(Mastercard), clock)
+	val := card.Validate()
+	assert.True(val.HasError(ErrTypeMismatch))
+	assert.Equal(Visa, val.DetectedType)
+
+	card.Apply(WithTypeDetection(true))
+	assert.True(card.Validate().HasError(ErrTypeMismatch))
+
+	// Without type detection the given card type is trusted
+	card.Apply(WithTypeDetection(false))
+	val = card.Validate()
+	assert.False(val.HasError(ErrTypeMismatch))
+	assert.True(val.Valid())
+	assert.Equal(Mastercard, val.DetectedType)
+
+	// Rules of the given card type still apply
+	card = NewCard("4012888888881881", WithExpiry(11, 2020), WithCVV("123"), WithType(AmericanExpress), clock, WithTypeDetection(false))
+	val = card.Validate()
+	assert.True(val.HasError(ErrInvalidLength))
+	assert.True(val.HasError(ErrCVVMismatch))
+
+	// Unknown names from legacy data are accepted
+	card = NewCard("4012888888881881", WithExpiry(11, 2020), WithCVV("123"), clock, WithTypeDetection(false))
+	card.Type = "VISA CREDIT"
+	val = card.Validate()
+	assert.True(val.Valid())
+	assert.Equal(Unknown, val.DetectedType)
+	assert.Equal("VISA CREDIT", val.Card.Type)
+
+	// Cards without a given card type are still detected
+	card = NewCard("4012888888881881", WithExpiry(11, 2020), WithCVV("123"), clock, WithTypeDetection(false))
+	val = card.Validate()
+	assert.True(val.Valid())
+	assert.Equal(Visa, val.DetectedType)
+	assert.Equal("Visa", val.Card.Type)
+}
+
+func TestWithAcceptedTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) })
+	accepted := WithAcceptedTypes(Visa, Mastercard)
+
+	for _, number := range []string{"4012888888881881", "5555555555554444"} {
+		card := NewCard(number, WithExpiry(11, 2020), WithCVV("123"), clock, accepted)
+		assert.True(card.IsAcceptedBy([]CardType{Visa, Mastercard}), number)
+		val := card.Validate()
+		assert.True(val.Valid(), number)
+		assert.False(val.HasError(ErrTypeNotAccepted), number)
+	}
+
+	card := NewCard("378282246310005", WithExpiry(11, 2020), WithCVV("1234"), clock, accepted)
+	assert.False(card.IsAcceptedBy([]CardType{Visa, Mastercard}))
+	val := card.Validate()
+	assert.False(val.Valid())
+	assert.True(val.HasError(ErrTypeNotAccepted))
+	assert.Equal([]string{"brand not accepted"}, val.Errors)
+
+	// Without the option all card types are accepted
+	card = NewCard("378282246310005", WithExpiry(11, 2020), WithCVV("1234"), clock)
+	assert.True(card.Validate().Valid())
+
+	card = NewCard("0000000000000000")
+	assert.False(card.IsAcceptedBy([]CardType{Visa, Mastercard, Unknown}))
+}
+
+func TestWithCVVRequired(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) })
+
+	// By default a CVV is required
+	card := NewCard("4012888888881881", WithExpiry(11, 2020), clock)
+	val := card.Validate()
+	assert.False(val.ValidCVV)
+	assert.True(val.HasError(ErrCVVMismatch))
+
+	card.Apply(WithCVVRequired(true))
+	assert.False(card.Validate().ValidCVV)
+
+	// Card-on-file transactions don't have a CVV
+	card.Apply(WithCVVRequired(false))
+	val = card.Validate()
+	assert.True(val.ValidCVV)
+	assert.True(val.Valid())
+	assert.Empty(val.Errors)
+
+	// A CVV that is given is still validated
+	card.Apply(WithCVV("12"))
+	val = card.Validate()
+	assert.False(val.ValidCVV)
+	assert.True(val.HasError(ErrCVVMismatch))
+}
+
+func TestWithExpiryGrace(t *testing.T) {
+	assert := assert.New(t)
+
+	days := func(n int) time.Duration { return time.Duration(n) * 24 * time.Hour }
+
+	// The card expired at the end of September, 15 days ago
+	card := NewCard("4012888888881881", WithExpiry(9, 2020), WithCVV("123"),
+		WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) }))
+	val := card.Validate()
+	assert.True(val.IsExpired)
+	assert.True(val.HasError(ErrExpired))
+
+	card.Apply(WithExpiryGrace(days(45)))
+	val = card.Validate()
+	assert.False(val.IsExpired)
+	assert.True(val.Valid())
+
+	// The grace period ends 45 days after the end of the expiry month
+	card.Apply(WithClock(func() time.Time { return time.Date(2020, time.November, 14, 23, 59, 59, 0, time.UTC) }))
+	assert.False(card.Validate().IsExpired)
+	card.Apply(WithClock(func() time.Time { return time.Date(2020, time.November, 15, 0, 0, 0, 0, time.UTC) }))
+	assert.True(card.Validate().IsExpired)
+
+	// The grace period extends into the next year for cards that expire in December
+	card = NewCard("4012888888881881", WithExpiry(12, 2025), WithCVV("123"), WithExpiryGrace(days(45)),
+		WithClock(func() time.Time { return time.Date(2026, time.January, 10, 0, 0, 0, 0, time.UTC) }))
+	val = card.Validate()
+	assert.False(val.IsExpired)
+	assert.True(val.ValidExpiryYear)
+	assert.True(val.Valid())
+	assert.Empty(val.Errors)
+
+	// A card in its grace period expires soon when the grace period ends soon
+	assert.False(card.IsExpiringSoon(days(30)))
+	assert.True(card.IsExpiringSoon(days(36)))
+	assert.False(val.HasWarning(ErrExpiringSoon))
+
+	card.Apply(WithClock(func() time.Time { return time.Date(2026, time.February, 15, 0, 0, 0, 0, time.UTC) }))
+	val = card.Validate()
+	assert.True(val.IsExpired)
+	assert.True(val.HasError(ErrExpired))
+	assert.False(card.IsExpiringSoon(days(30)))
+}
+
+func TestWithExpectedCVVLength(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) })
+
+	card := NewCard("4012888888881881", WithExpiry(11, 2020), WithCVV("1234"), clock)
+	assert.True(card.CVVLengthMatches(4))
+	assert.False(card.CVVLengthMatches(3))
+	assert.False(card.Validate().ValidCVV)
+
+	card.Apply(WithExpectedCVVLength(4))
+	val := card.Validate()
+	assert.True(val.ValidCVV)
+	assert.True(val.Valid())
+
+	card.Apply(WithCVV("123"))
+	val = card.Validate()
+	assert.False(val.ValidCVV)
+	assert.True(val.HasError(ErrCVVMismatch))
+
+	card.Apply(WithCVV(" 1234 "))
+	assert.True(card.CVVLengthMatches(4))
+	assert.True(card.Validate().ValidCVV)
+}
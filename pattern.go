@@ -0,0 +1,36 @@
+package creditcard
+
+// suspiciousRunLength is the number of identical or sequential digits in a row that makes a card number suspicious
+const suspiciousRunLength = 7
+
+// IsSuspiciousPattern reports whether the card number looks made up, because the digits after the BIN contain a long
+// run of identical digits, like 4111111111111111, or of sequential digits, like 4012341234567890. The check digit is
+// not taken into account. This is a heuristic for fraud screening: actual cards can have such numbers, and numbers
+// without such patterns can still be fake
+func (c *Card) IsSuspiciousPattern() bool {
+	number := c.number()
+	if len(number) <= defaultBINLength+1 || !isDigits(number) {
+		return false
+	}
+	body := number[defaultBINLength : len(number)-1]
+
+	same, up, down := 1, 1, 1
+	for i := 1; i < len(body); i++ {
+		diff := (int(body[i]) - int(body[i-1]) + 10) % 10
+		same = nextRun(same, diff == 0)
+		up = nextRun(up, diff == 1)
+		down = nextRun(down, diff == 9)
+		if same >= suspiciousRunLength || up >= suspiciousRunLength || down >= suspiciousRunLength {
+			return true
+		}
+	}
+	return false
+}
+
+// nextRun returns the length of a run of digits after the next digit, which either continues the run or starts a new one
+func nextRun(run int, continues bool) int {
+	if continues {
+		return run + 1
+	}
+	return 1
+}
@@ -0,0 +1,36 @@
+package creditcard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSuspiciousPattern(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, number := range []string{
+		"4111111111111111",
+		"4111 1111 1111 1111",
+		"4000000000000002",
+		"4012341234567890",
+		"4012349876543210",
+		"5555557890123456",
+	} {
+		card := Card{Number: number}
+		assert.True(card.IsSuspiciousPattern(), number)
+	}
+
+	for _, number := range []string{
+		"4012888888881881",
+		"4532015112830366",
+		"378282246310005",
+		"5105105105105100",
+		"4111111",
+		"4111a11111111111",
+		"",
+	} {
+		card := Card{Number: number}
+		assert.False(card.IsSuspiciousPattern(), number)
+	}
+}
@@ -0,0 +1,82 @@
+package creditcard
+
+import "sync"
+
+// registeredType is a card type that is registered at runtime
+type registeredType struct {
+	// name is the display name of the card type
+	name string
+	// matcher reports whether a card number belongs to the card type
+	matcher func(number string) bool
+}
+
+// registry contains the card types that are registered at runtime
+var registry struct {
+	sync.RWMutex
+	types []registeredType
+}
+
+// RegisterCardType registers a card type, like a private label card, that is not supported out of the box
+// and returns the CardType assigned to it. The matcher is called with the card number, without spaces
+// and dashes, and reports whether the number belongs to the card type. Registered card types are only
+// considered when the number doesn't match any of the built-in card types, in the order in which they
+// were registered. It is safe to register card types while cards are being validated
+func RegisterCardType(name string, matcher func(number string) bool) CardType {
+	registry.Lock()
+	defer registry.Unlock()
+
+	registry.types = append(registry.types, registeredType{name: name, matcher: matcher})
+	return CardType(len(cardTypeNames) + len(registry.types) - 1)
+}
+
+// registeredCardType returns the first registered card type that matches the number
+func registeredCardType(number string) (CardType, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	for i, t := range registry.types {
+		if t.matcher(number) {
+			return CardType(len(cardTypeNames) + i), true
+		}
+	}
+	return Unknown, false
+}
+
+// registeredCardTypes returns all registered card types that match the number
+func registeredCardTypes(number string) []CardType {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	cardTypes := make([]CardType, 0)
+	for i, t := range registry.types {
+		if t.matcher(number) {
+			cardTypes = append(cardTypes, CardType(len(cardTypeNames)+i))
+		}
+	}
+	return cardTypes
+}
+
+// registeredCardTypeName returns the name of a registered card type
+func registeredCardTypeName(cardType CardType) (string, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	i := int(cardType) - len(cardTypeNames)
+	if i < 0 || i >= len(registry.types) {
+		return "", false
+	}
+	return registry.types[i].name, true
+}
+
+// registeredCardTypeNamed returns the first registered card type with the name, ignoring case and whitespace
+func registeredCardTypeNamed(name string) (CardType, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	for i, t := range registry.types {
+		if sameTypeName(name, t.name) {
+			return CardType(len(cardTypeNames) + i), true
+		}
+	}
+	return Unknown, false
+}
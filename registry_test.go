@@ -0,0 +1,100 @@
+package creditcard
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// restoreRegistry restores the registered card types when the test finishes, so card types that are registered
+// by the test don't affect other tests
+func restoreRegistry(t *testing.T) {
+	registry.RLock()
+	types := append([]registeredType(nil), registry.types...)
+	registry.RUnlock()
+
+	t.Cleanup(func() {
+		registry.Lock()
+		defer registry.Unlock()
+		registry.types = types
+	})
+}
+
+func TestRegisterCardType(t *testing.T) {
+	assert := assert.New(t)
+	restoreRegistry(t)
+
+	storeCard := RegisterCardType("StoreCard", func(number string) bool {
+		return strings.HasPrefix(number, "7777")
+	})
+	assert.Equal("StoreCard", storeCard.String())
+
+	card := Card{
+		Number: "7777 1234 5678 9011", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	cardType, err := card.DetectType()
+	assert.NoError(err)
+	assert.Equal(storeCard, cardType)
+
+	val := card.Validate()
+	assert.Equal("StoreCard", val.Card.Type)
+	assert.True(val.ValidCardNumber)
+
+	// Built-in card types take precedence over registered card types
+	visaLike := RegisterCardType("VisaLike", func(number string) bool {
+		return number == "4012888888881881"
+	})
+	assert.NotEqual(storeCard, visaLike)
+	card = Card{Number: "4012888888881881"}
+	cardType, err = card.DetectType()
+	assert.NoError(err)
+	assert.Equal(Visa, cardType)
+
+	// Registered card types are matched in the order in which they were registered
+	otherStoreCard := RegisterCardType("OtherStoreCard", func(number string) bool {
+		return strings.HasPrefix(number, "777")
+	})
+	card = Card{Number: "7777123456789011"}
+	cardType, _ = card.DetectType()
+	assert.Equal(storeCard, cardType)
+	card = Card{Number: "7771234567890123"}
+	cardType, _ = card.DetectType()
+	assert.Equal(otherStoreCard, cardType)
+
+	assert.Equal("Unknown Card", CardType(-1).String())
+	assert.Equal("Unknown Card", CardType(1000).String())
+}
+
+func TestRegisterCardTypeConcurrently(t *testing.T) {
+	assert := assert.New(t)
+	restoreRegistry(t)
+
+	card := Card{Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2030, CVV: "123"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			prefix := fmt.Sprintf("88%02d", i)
+			cardType := RegisterCardType(fmt.Sprintf("ConcurrentCard%d", i), func(number string) bool {
+				return strings.HasPrefix(number, prefix)
+			})
+			assert.Equal(fmt.Sprintf("ConcurrentCard%d", i), cardType.String())
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				val := card.Validate()
+				assert.Equal(Visa, val.DetectedType)
+				other := Card{Number: "8800123456789012"}
+				_ = other.PossibleTypes()
+				_, _ = other.DetectType()
+			}
+		}()
+	}
+	wg.Wait()
+}
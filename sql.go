@@ -0,0 +1,63 @@
+package creditcard
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// cardRecord is the representation of a card that is stored in a database. The CVV is never stored
+type cardRecord struct {
+	Type        string
+	Number      string
+	ExpiryMonth int
+	ExpiryYear  int
+}
+
+// Value implements the driver.Valuer interface, so a card can be stored in a database. The card is stored
+// as a JSON document with the type, number, and expiry of the card. The CVV is never stored and the number
+// is masked when the card is configured with WithMaskedValue
+func (c Card) Value() (driver.Value, error) {
+	record := cardRecord{
+		Type:        c.Type,
+		Number:      c.Number,
+		ExpiryMonth: c.ExpiryMonth,
+		ExpiryYear:  c.ExpiryYear,
+	}
+	if c.opts.maskValue {
+		record.Number = c.MaskNumber()
+	}
+
+	b, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface, so a card can be loaded from a database column
+// that was stored using Value. A NULL value results in an empty card
+func (c *Card) Scan(src interface{}) error {
+	var record cardRecord
+
+	switch src := src.(type) {
+	case nil:
+	case string:
+		if err := json.Unmarshal([]byte(src), &record); err != nil {
+			return fmt.Errorf("cannot scan card: %s", err.Error())
+		}
+	case []byte:
+		if err := json.Unmarshal(src, &record); err != nil {
+			return fmt.Errorf("cannot scan card: %s", err.Error())
+		}
+	default:
+		return fmt.Errorf("cannot scan type %T into a card", src)
+	}
+
+	c.Type = record.Type
+	c.Number = record.Number
+	c.ExpiryMonth = record.ExpiryMonth
+	c.ExpiryYear = record.ExpiryYear
+	c.CVV = ""
+	return nil
+}
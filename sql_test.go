@@ -0,0 +1,51 @@
+package creditcard
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ driver.Valuer = Card{}
+	_ sql.Scanner   = &Card{}
+)
+
+func TestValueScan(t *testing.T) {
+	assert := assert.New(t)
+
+	card := Card{
+		Type: "Visa", Number: "4012888888881881", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "123",
+	}
+	value, err := card.Value()
+	assert.NoError(err)
+	assert.IsType("", value)
+	assert.NotContains(value, "CVV")
+
+	var scanned Card
+	assert.NoError(scanned.Scan(value))
+	assert.Equal("Visa", scanned.Type)
+	assert.Equal("4012888888881881", scanned.Number)
+	assert.Equal(11, scanned.ExpiryMonth)
+	assert.Equal(2020, scanned.ExpiryYear)
+	assert.Empty(scanned.CVV)
+
+	scanned = Card{}
+	assert.NoError(scanned.Scan([]byte(value.(string))))
+	assert.Equal("4012888888881881", scanned.Number)
+
+	card.Apply(WithMaskedValue())
+	value, err = card.Value()
+	assert.NoError(err)
+	assert.NotContains(value, "4012888888881881")
+	assert.NoError(scanned.Scan(value))
+	assert.Equal("************1881", scanned.Number)
+
+	assert.NoError(scanned.Scan(nil))
+	assert.Equal(Card{}, scanned)
+
+	assert.Error(scanned.Scan("not json"))
+	assert.Error(scanned.Scan(42))
+}
@@ -0,0 +1,52 @@
+package creditcard
+
+// testCardNumbers contains card numbers that are documented as test numbers by payment processors,
+// together with the processor that documents them. Numbers documented by several processors are
+// listed with the first processor that published them
+var testCardNumbers = map[string]string{
+	// https://www.paypalobjects.com/en_US/vhelp/paypalmanager_help/credit_card_numbers.htm
+	"378282246310005":  "PayPal",
+	"371449635398431":  "PayPal",
+	"378734493671000":  "PayPal",
+	"5610591081018250": "PayPal",
+	"30569309025904":   "PayPal",
+	"38520000023237":   "PayPal",
+	"6011111111111117": "PayPal",
+	"6011000990139424": "PayPal",
+	"3530111333300000": "PayPal",
+	"3566002020360505": "PayPal",
+	"5555555555554444": "PayPal",
+	"5105105105105100": "PayPal",
+	"4111111111111111": "PayPal",
+	"4012888888881881": "PayPal",
+	"4222222222222":    "PayPal",
+	"5019717010103742": "PayPal",
+	"6331101999990016": "PayPal",
+	// https://stripe.com/docs/testing
+	"4242424242424242": "Stripe",
+	"4000056655665556": "Stripe",
+	"5200828282828210": "Stripe",
+	"2223003122003222": "Stripe",
+	"6011981111111113": "Stripe",
+	"3056930009020004": "Stripe",
+	"36227206271667":   "Stripe",
+	"6200000000000005": "Stripe",
+	"4000000000000002": "Stripe",
+	"4000000000009995": "Stripe",
+	"4000000000000069": "Stripe",
+	"4000000000000127": "Stripe",
+}
+
+// IsTestCard reports whether the card number is a well-known test number published by a payment processor.
+// Such numbers pass validation, but don't belong to actual cards
+func (c *Card) IsTestCard() bool {
+	_, ok := c.TestCardProcessor()
+	return ok
+}
+
+// TestCardProcessor returns the name of the payment processor that published the card number as a test number.
+// The second return value is false when the card number is not a known test number
+func (c *Card) TestCardProcessor() (string, bool) {
+	processor, ok := testCardNumbers[c.number()]
+	return processor, ok
+}
@@ -0,0 +1,37 @@
+package creditcard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTestCard(t *testing.T) {
+	assert := assert.New(t)
+
+	for number, processor := range map[string]string{
+		"4111111111111111":    "PayPal",
+		"4111 1111 1111 1111": "PayPal",
+		"4012888888881881":    "PayPal",
+		"5555555555554444":    "PayPal",
+		"4242424242424242":    "Stripe",
+		"4000000000000002":    "Stripe",
+		"2223003122003222":    "Stripe",
+	} {
+		card := Card{Number: number}
+		assert.True(card.IsTestCard(), number)
+		name, ok := card.TestCardProcessor()
+		assert.True(ok)
+		assert.Equal(processor, name, number)
+	}
+
+	card := Card{Number: "4012888888881882"}
+	assert.False(card.IsTestCard())
+	name, ok := card.TestCardProcessor()
+	assert.False(ok)
+	assert.Empty(name)
+
+	for number := range testCardNumbers {
+		assert.True(Luhn(number), number)
+	}
+}
@@ -0,0 +1,18 @@
+package creditcard
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Token returns a deterministic token for the card number, which is the hex encoded HMAC-SHA256 of the card number,
+// without spaces and dashes, using the salt as key. The same card number and salt always result in the same token,
+// so the token can be stored to recognize a card without storing the card number. The token is meant for matching
+// cards only, the card number can't be recovered from it. Keep the salt secret, because the number of possible
+// card numbers is small enough to find the card number of a token by trying them all when the salt is known
+func (c *Card) Token(salt []byte) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(c.number()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
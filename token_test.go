@@ -0,0 +1,28 @@
+package creditcard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken(t *testing.T) {
+	assert := assert.New(t)
+
+	salt := []byte("secret")
+
+	card := Card{Number: "4012888888881881"}
+	token := card.Token(salt)
+	assert.Len(token, 64)
+	assert.NotContains(token, card.Number)
+
+	// The same number results in the same token, regardless of formatting
+	assert.Equal(token, card.Token(salt))
+	other := Card{Number: "4012 8888 8888 1881"}
+	assert.Equal(token, other.Token(salt))
+
+	// Different numbers and salts result in different tokens
+	other = Card{Number: "4111111111111111"}
+	assert.NotEqual(token, other.Token(salt))
+	assert.NotEqual(token, card.Token([]byte("other")))
+}
@@ -0,0 +1,25 @@
+package creditcard
+
+// Validator validates cards with a fixed configuration, so a service can configure a validator once at startup and
+// reuse it for every card, instead of applying the same options to each card. A Validator is safe for concurrent use.
+// Card types registered with RegisterCardType are available to all validators
+type Validator struct {
+	// opts are the options that are applied to every card that is validated
+	opts []Option
+}
+
+// NewValidator creates a validator that applies the options, like WithClock, WithAcceptedTypes,
+// WithCVVRequired, or WithMaskRune, to every card it validates
+func NewValidator(opts ...Option) *Validator {
+	return &Validator{
+		opts: append([]Option(nil), opts...),
+	}
+}
+
+// Validate validates a copy of the card with the options of the validator, which take precedence over the options
+// of the card itself. The card that is passed in is never changed
+func (v *Validator) Validate(c *Card) *Validation {
+	card := *c
+	card.Apply(v.opts...)
+	return card.Validate()
+}
@@ -0,0 +1,35 @@
+package creditcard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidator(t *testing.T) {
+	assert := assert.New(t)
+
+	validator := NewValidator(
+		WithClock(func() time.Time { return time.Date(2020, time.October, 15, 0, 0, 0, 0, time.UTC) }),
+		WithAcceptedTypes(Visa, Mastercard),
+		WithCVVRequired(false),
+		WithMaskRune('X'),
+	)
+
+	for _, number := range []string{"4012888888881881", "5555555555554444"} {
+		card := &Card{Number: number, ExpiryMonth: 11, ExpiryYear: 2020}
+		val := validator.Validate(card)
+		assert.True(val.Valid(), number)
+		assert.Equal("XXXXXXXXXXXX"+number[12:], val.Card.MaskNumber())
+	}
+
+	card := &Card{Number: "378282246310005", ExpiryMonth: 11, ExpiryYear: 2020, CVV: "1234"}
+	val := validator.Validate(card)
+	assert.False(val.Valid())
+	assert.True(val.HasError(ErrTypeNotAccepted))
+
+	// The card itself is not changed
+	assert.Nil(card.opts.acceptedTypes)
+	assert.True(card.Validate().HasError(ErrExpired))
+}